@@ -0,0 +1,421 @@
+package datetime
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Date is a calendar date without a time or location, like a birthday.  Unlike a time.Time at
+// midnight, it doesn't change when converted between locations.  The zero value has no date.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// ParseDate takes a string with an ISO 8601 date in it, like 2007-11-30, 20071130, 2007-334, or
+// 2007-W48-5, and returns a Date.  The date must have a day, and no time.  Options that affect dates,
+// like Lenient and ExpandedYears, are allowed.
+func ParseDate(s string, opts ...Option) (Date, error) {
+	return parseDateBytes([]byte(s), opts)
+}
+
+// DateOf returns the date that t falls on in its location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// In returns the time.Time at the start of the date in the given location.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns the date n days later, or earlier if n is negative.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// AddMonths returns the date n months later, or earlier if n is negative.  Like time.Time.AddDate, it
+// normalizes days past the end of the month, so January 31st plus one month is March 3rd (or 2nd in a
+// leap year).
+func (d Date) AddMonths(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, n, 0))
+}
+
+// Before tells you whether d is before other.
+func (d Date) Before(other Date) bool {
+	if d.Year != other.Year {
+		return d.Year < other.Year
+	}
+	if d.Month != other.Month {
+		return d.Month < other.Month
+	}
+	return d.Day < other.Day
+}
+
+// After tells you whether d is after other.
+func (d Date) After(other Date) bool {
+	return other.Before(d)
+}
+
+// IsZero tells you whether d is the zero value.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// String returns the date in the ISO 8601 extended format, like 2007-11-30.  Years outside 0000-9999
+// are written as expanded years, like -000044-03-15.
+func (d Date) String() string {
+	return fmt.Sprintf("%s-%02d-%02d", formatYear(d.Year), int(d.Month), d.Day)
+}
+
+// MarshalText implements the encoding TextMarshaler interface.  The zero value is written as an
+// empty string.
+func (d Date) MarshalText() ([]byte, error) {
+	if d.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding TextUnmarshaler interface.  An empty string is read as the
+// zero value.
+func (d *Date) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := parseDateBytes(data, expandedYearsIn(data))
+	*d = parsed
+	return err
+}
+
+// MarshalJSON implements the JSON Marshaler interface, writing the date as a JSON string.  The zero
+// value is written as null, to match UnmarshalJSON.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the JSON Unmarshaler interface, allowing datetime.Date struct fields to be
+// read from JSON string fields.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	// Ignore null, like in the main JSON package.
+	if string(data) == "null" {
+		*d = Date{}
+		return nil
+	}
+	trimmed, err := trimQuotes(data)
+	if err != nil {
+		*d = Date{}
+		return err
+	}
+	return d.UnmarshalText(trimmed)
+}
+
+// Scan implements the sql Scanner interface, allowing datetime.Date fields to be read from DATE
+// database columns, which drivers may return as a time.Time, string, or []byte.  NULL is read as the
+// zero value.
+func (d *Date) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = Date{}
+		return nil
+	case time.Time:
+		*d = DateOf(v)
+		return nil
+	}
+	b, err := scanBytes(value)
+	if err != nil {
+		*d = Date{}
+		return err
+	}
+	return d.UnmarshalText(b)
+}
+
+// Value implements the sql Valuer interface, allowing datetime.Date fields to be saved to database
+// columns.  The zero value is saved as NULL.
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.String(), nil
+}
+
+// YearMonth is a month of a year without a day, time, or location, like a billing month.  The zero
+// value has no month.
+type YearMonth struct {
+	Year  int
+	Month time.Month
+}
+
+// ParseYearMonth takes a string with an ISO 8601 year and month in it, like 2007-11, and returns a
+// YearMonth.  See ParseDate for the options that are allowed.
+func ParseYearMonth(s string, opts ...Option) (YearMonth, error) {
+	return parseYearMonthBytes([]byte(s), opts)
+}
+
+// YearMonthOf returns the year and month that t falls in in its location.
+func YearMonthOf(t time.Time) YearMonth {
+	return YearMonth{Year: t.Year(), Month: t.Month()}
+}
+
+// In returns the time.Time at the start of the month in the given location.
+func (ym YearMonth) In(loc *time.Location) time.Time {
+	return time.Date(ym.Year, ym.Month, 1, 0, 0, 0, 0, loc)
+}
+
+// AddMonths returns the month n months later, or earlier if n is negative.
+func (ym YearMonth) AddMonths(n int) YearMonth {
+	return YearMonthOf(ym.In(time.UTC).AddDate(0, n, 0))
+}
+
+// Before tells you whether ym is before other.
+func (ym YearMonth) Before(other YearMonth) bool {
+	if ym.Year != other.Year {
+		return ym.Year < other.Year
+	}
+	return ym.Month < other.Month
+}
+
+// After tells you whether ym is after other.
+func (ym YearMonth) After(other YearMonth) bool {
+	return other.Before(ym)
+}
+
+// IsZero tells you whether ym is the zero value.
+func (ym YearMonth) IsZero() bool {
+	return ym == YearMonth{}
+}
+
+// String returns the month in the ISO 8601 extended format, like 2007-11.  Years outside 0000-9999 are
+// written as expanded years, like +012007-11.
+func (ym YearMonth) String() string {
+	return fmt.Sprintf("%s-%02d", formatYear(ym.Year), int(ym.Month))
+}
+
+// MarshalText implements the encoding TextMarshaler interface.  The zero value is written as an
+// empty string.
+func (ym YearMonth) MarshalText() ([]byte, error) {
+	if ym.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(ym.String()), nil
+}
+
+// UnmarshalText implements the encoding TextUnmarshaler interface.  An empty string is read as the
+// zero value.
+func (ym *YearMonth) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*ym = YearMonth{}
+		return nil
+	}
+	parsed, err := parseYearMonthBytes(data, expandedYearsIn(data))
+	*ym = parsed
+	return err
+}
+
+// MarshalJSON implements the JSON Marshaler interface, writing the month as a JSON string.  The zero
+// value is written as null, to match UnmarshalJSON.
+func (ym YearMonth) MarshalJSON() ([]byte, error) {
+	if ym.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + ym.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the JSON Unmarshaler interface, allowing datetime.YearMonth struct fields
+// to be read from JSON string fields.
+func (ym *YearMonth) UnmarshalJSON(data []byte) error {
+	// Ignore null, like in the main JSON package.
+	if string(data) == "null" {
+		*ym = YearMonth{}
+		return nil
+	}
+	trimmed, err := trimQuotes(data)
+	if err != nil {
+		*ym = YearMonth{}
+		return err
+	}
+	return ym.UnmarshalText(trimmed)
+}
+
+// Scan implements the sql Scanner interface, allowing datetime.YearMonth fields to be read from
+// database columns.  A time.Time is truncated to its month, and NULL is read as the zero value.
+func (ym *YearMonth) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*ym = YearMonth{}
+		return nil
+	case time.Time:
+		*ym = YearMonthOf(v)
+		return nil
+	}
+	b, err := scanBytes(value)
+	if err != nil {
+		*ym = YearMonth{}
+		return err
+	}
+	return ym.UnmarshalText(b)
+}
+
+// Value implements the sql Valuer interface, allowing datetime.YearMonth fields to be saved to
+// database columns.  The zero value is saved as NULL.
+func (ym YearMonth) Value() (driver.Value, error) {
+	if ym.IsZero() {
+		return nil, nil
+	}
+	return ym.String(), nil
+}
+
+// Year is a calendar year without a month, day, time, or location.  Years are numbered
+// astronomically, so year 0 is 1 BC.
+type Year int
+
+// ParseYear takes a string with an ISO 8601 year in it, like 2007, and returns a Year.  See
+// ParseDate for the options that are allowed.
+func ParseYear(s string, opts ...Option) (Year, error) {
+	year, _, _, err := parseCivil([]byte(s), opts, PrecisionYear)
+	return Year(year), err
+}
+
+// In returns the time.Time at the start of the year in the given location.
+func (y Year) In(loc *time.Location) time.Time {
+	return time.Date(int(y), time.January, 1, 0, 0, 0, 0, loc)
+}
+
+// String returns the year with four digits, like 2007, or as an expanded year like -000044 if it's
+// outside 0000-9999.
+func (y Year) String() string {
+	return formatYear(int(y))
+}
+
+// MarshalText implements the encoding TextMarshaler interface.
+func (y Year) MarshalText() ([]byte, error) {
+	return []byte(y.String()), nil
+}
+
+// UnmarshalText implements the encoding TextUnmarshaler interface.
+func (y *Year) UnmarshalText(data []byte) error {
+	parsed, err := ParseYear(string(data), expandedYearsIn(data)...)
+	*y = parsed
+	return err
+}
+
+// MarshalJSON implements the JSON Marshaler interface, writing the year as a JSON string.
+func (y Year) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + y.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the JSON Unmarshaler interface, allowing datetime.Year struct fields to be
+// read from JSON string fields.  null is read as year 0.
+func (y *Year) UnmarshalJSON(data []byte) error {
+	// Ignore null, like in the main JSON package.
+	if string(data) == "null" {
+		*y = 0
+		return nil
+	}
+	trimmed, err := trimQuotes(data)
+	if err != nil {
+		*y = 0
+		return err
+	}
+	return y.UnmarshalText(trimmed)
+}
+
+// Scan implements the sql Scanner interface, allowing datetime.Year fields to be read from database
+// columns.  A time.Time is truncated to its year, and NULL is read as year 0, like UnmarshalJSON
+// reads null.
+func (y *Year) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*y = 0
+		return nil
+	case time.Time:
+		*y = Year(v.Year())
+		return nil
+	}
+	b, err := scanBytes(value)
+	if err != nil {
+		*y = 0
+		return err
+	}
+	return y.UnmarshalText(b)
+}
+
+// Value implements the sql Valuer interface, allowing datetime.Year fields to be saved to database
+// columns.
+func (y Year) Value() (driver.Value, error) {
+	return y.String(), nil
+}
+
+func parseDateBytes(b []byte, opts []Option) (Date, error) {
+	year, month, day, err := parseCivil(b, opts, PrecisionDay)
+	if err != nil {
+		return Date{}, err
+	}
+	// a Lenient date like 2007-02-29 is normalized like time.Date would.
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC)), nil
+}
+
+func parseYearMonthBytes(b []byte, opts []Option) (YearMonth, error) {
+	year, month, _, err := parseCivil(b, opts, PrecisionMonth)
+	return YearMonth{Year: year, Month: month}, err
+}
+
+// parseCivil parses a date with nothing after it, which must have the given precision.
+func parseCivil(b []byte, opts []Option, precision Precision) (int, time.Month, int, error) {
+	o := newOptions(opts)
+	o.precisions = []Precision{precision}
+	p := newParser(b, o)
+	parseErr := func(err error) (int, time.Month, int, error) {
+		return 0, time.Month(0), 0, err
+	}
+
+	year, month, day, err := p.parseDate()
+	if err != nil {
+		return parseErr(err)
+	}
+	if tok, _ := p.scan(); tok != EOF {
+		return parseErr(p.unexpected("EOF"))
+	}
+	if err := p.checkDate(year, month, day); err != nil {
+		return parseErr(err)
+	}
+	if err := p.checkPrecision(); err != nil {
+		return parseErr(err)
+	}
+	return year, month, day, nil
+}
+
+// formatYear returns the year with four digits, or if it doesn't fit in them, as an ISO 8601
+// expanded year with a sign and at least two extra digits.  expandedYearsIn reads those back.
+func formatYear(year int) string {
+	if year < 0 || year > 9999 {
+		return fmt.Sprintf("%+07d", year)
+	}
+	return fmt.Sprintf("%04d", year)
+}
+
+// expandedYearsIn returns the ExpandedYears option that the year at the start of a date written by
+// formatYear needs, if it has a sign.
+func expandedYearsIn(data []byte) []Option {
+	if len(data) == 0 || (data[0] != '+' && data[0] != '-') {
+		return nil
+	}
+	digits := 0
+	for _, c := range data[1:] {
+		if !isDigit(c) {
+			break
+		}
+		digits++
+	}
+	if digits <= 4 {
+		return nil
+	}
+	return []Option{ExpandedYears(digits - 4)}
+}
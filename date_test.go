@@ -0,0 +1,221 @@
+package datetime
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDate(t *testing.T) {
+	tt := []struct {
+		input  string
+		opts   []Option
+		output Date
+	}{
+		{input: "2007-11-30", output: Date{2007, time.November, 30}},
+		{input: "20071130", output: Date{2007, time.November, 30}},
+		{input: "2007-334", output: Date{2007, time.November, 30}},
+		{input: "2007334", output: Date{2007, time.November, 30}},
+		{input: "2007-W48-5", output: Date{2007, time.November, 30}},
+		{input: "2007W485", output: Date{2007, time.November, 30}},
+		{input: "2007-02-29", opts: []Option{Lenient()}, output: Date{2007, time.March, 1}},
+		{input: "-000044-03-15", opts: []Option{ExpandedYears(2)}, output: Date{-44, time.March, 15}},
+	}
+
+	for _, tc := range tt {
+		d, err := ParseDate(tc.input, tc.opts...)
+		assert.Equal(t, tc.output, d, tc.input)
+		assert.Nil(t, err, tc.input)
+	}
+
+	errs := []struct {
+		input string
+		err   string
+	}{
+		{input: "2007-11", err: "month precision is not allowed"},
+		{input: "2007-W48", err: "week precision is not allowed"},
+		{input: "2007-11-30T10:10", err: "found T, expected EOF"},
+		{input: "2007-02-29", err: "29 is not a valid day in February 2007"},
+		{input: "2007-13-01", err: "13 is not a valid month"},
+	}
+
+	for _, tc := range errs {
+		d, err := ParseDate(tc.input)
+		assert.Equal(t, Date{}, d, tc.input)
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+}
+
+func TestDate(t *testing.T) {
+	d := Date{2007, time.November, 30}
+	est := time.FixedZone("EST", -5*60*60)
+
+	assert.Equal(t, time.Date(2007, time.November, 30, 0, 0, 0, 0, est), d.In(est))
+	assert.Equal(t, d, DateOf(time.Date(2007, time.November, 30, 23, 59, 0, 0, est)))
+	assert.Equal(t, Date{2007, time.December, 1}, d.AddDays(1))
+	assert.Equal(t, Date{2007, time.November, 1}, d.AddDays(-29))
+	assert.Equal(t, Date{2008, time.February, 1}, Date{2007, time.November, 1}.AddMonths(3))
+	assert.Equal(t, Date{2008, time.March, 2}, Date{2008, time.January, 31}.AddMonths(1))
+	assert.True(t, d.Before(Date{2007, time.December, 1}))
+	assert.True(t, d.After(Date{2007, time.November, 29}))
+	assert.False(t, d.Before(d))
+	assert.False(t, d.IsZero())
+	assert.True(t, Date{}.IsZero())
+	assert.Equal(t, "2007-11-30", d.String())
+	assert.Equal(t, "-000044-03-15", Date{-44, time.March, 15}.String())
+	assert.Equal(t, "+012007-11-30", Date{12007, time.November, 30}.String())
+}
+
+func TestExpandedYearsRoundTrip(t *testing.T) {
+	for _, d := range []Date{{-44, time.March, 15}, {12007, time.November, 30}, {-123456, time.January, 1}} {
+		var gotDate Date
+		text, err := d.MarshalText()
+		assert.Nil(t, err)
+		assert.Nil(t, gotDate.UnmarshalText(text), string(text))
+		assert.Equal(t, d, gotDate)
+
+		var gotYM YearMonth
+		ym := YearMonth{d.Year, d.Month}
+		data, err := ym.MarshalJSON()
+		assert.Nil(t, err)
+		assert.Nil(t, gotYM.UnmarshalJSON(data), string(data))
+		assert.Equal(t, ym, gotYM)
+
+		var gotYear Year
+		v, err := Year(d.Year).Value()
+		assert.Nil(t, err)
+		assert.Nil(t, gotYear.Scan(v), v)
+		assert.Equal(t, Year(d.Year), gotYear)
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	var out struct {
+		D  Date      `json:"d"`
+		YM YearMonth `json:"ym"`
+		Y  Year      `json:"y"`
+	}
+	err := json.Unmarshal([]byte(`{"d":"20071130","ym":"2007-11","y":"2007"}`), &out)
+	assert.Nil(t, err)
+	assert.Equal(t, Date{2007, time.November, 30}, out.D)
+	assert.Equal(t, YearMonth{2007, time.November}, out.YM)
+	assert.Equal(t, Year(2007), out.Y)
+
+	b, err := json.Marshal(out)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"d":"2007-11-30","ym":"2007-11","y":"2007"}`, string(b))
+
+	err = json.Unmarshal([]byte(`{"d":null,"ym":null,"y":null}`), &out)
+	assert.Nil(t, err)
+	b, err = json.Marshal(out)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"d":null,"ym":null,"y":"0000"}`, string(b))
+
+	err = json.Unmarshal([]byte(`{"d":"2007-11"}`), &out)
+	assert.EqualError(t, err, "month precision is not allowed")
+	err = json.Unmarshal([]byte(`{"ym":"2007-11-30"}`), &out)
+	assert.EqualError(t, err, "day precision is not allowed")
+	err = json.Unmarshal([]byte(`{"y":2007}`), &out)
+	assert.EqualError(t, err, "2007 does not begin and end with double quotes")
+}
+
+func TestDateText(t *testing.T) {
+	var d Date
+	assert.Nil(t, d.UnmarshalText([]byte("2007-334")))
+	b, err := d.MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, "2007-11-30", string(b))
+
+	assert.Nil(t, d.UnmarshalText([]byte{}))
+	b, err = d.MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, "", string(b))
+}
+
+func TestDateScanValue(t *testing.T) {
+	tt := []struct {
+		input  interface{}
+		output Date
+		err    string
+	}{
+		{input: "2007-11-30", output: Date{2007, time.November, 30}},
+		{input: []byte("20071130"), output: Date{2007, time.November, 30}},
+		{input: time.Date(2007, time.November, 30, 0, 0, 0, 0, time.UTC), output: Date{2007, time.November, 30}},
+		{input: nil},
+		{input: "2007", err: "year precision is not allowed"},
+		{input: 12, err: "can only scan string and []byte, not int"},
+	}
+
+	for _, tc := range tt {
+		d := Date{2000, time.January, 1}
+		err := d.Scan(tc.input)
+		assertErrorMessage(t, tc.err, err)
+		assert.Equal(t, tc.output, d)
+	}
+
+	v, err := Date{2007, time.November, 30}.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, driver.Value("2007-11-30"), v)
+
+	v, err = Date{}.Value()
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+}
+
+func TestYearMonth(t *testing.T) {
+	ym, err := ParseYearMonth("2007-11")
+	assert.Nil(t, err)
+	assert.Equal(t, YearMonth{2007, time.November}, ym)
+
+	_, err = ParseYearMonth("2007")
+	assert.EqualError(t, err, "year precision is not allowed")
+
+	assert.Equal(t, time.Date(2007, time.November, 1, 0, 0, 0, 0, time.UTC), ym.In(time.UTC))
+	assert.Equal(t, ym, YearMonthOf(time.Date(2007, time.November, 30, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, YearMonth{2008, time.January}, ym.AddMonths(2))
+	assert.Equal(t, YearMonth{2006, time.December}, ym.AddMonths(-11))
+	assert.True(t, ym.Before(YearMonth{2008, time.January}))
+	assert.True(t, ym.After(YearMonth{2007, time.October}))
+	assert.Equal(t, "2007-11", ym.String())
+
+	var scanned YearMonth
+	assert.Nil(t, scanned.Scan(time.Date(2007, time.November, 30, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ym, scanned)
+	assert.Nil(t, scanned.Scan([]byte("2007-12")))
+	assert.Equal(t, YearMonth{2007, time.December}, scanned)
+
+	v, err := ym.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, driver.Value("2007-11"), v)
+}
+
+func TestYear(t *testing.T) {
+	y, err := ParseYear("2007")
+	assert.Nil(t, err)
+	assert.Equal(t, Year(2007), y)
+
+	_, err = ParseYear("2007-11")
+	assert.EqualError(t, err, "month precision is not allowed")
+
+	y, err = ParseYear("-000044", ExpandedYears(2))
+	assert.Nil(t, err)
+	assert.Equal(t, Year(-44), y)
+	assert.Equal(t, "-000044", y.String())
+
+	assert.Equal(t, time.Date(2007, time.January, 1, 0, 0, 0, 0, time.UTC), Year(2007).In(time.UTC))
+
+	var scanned Year
+	assert.Nil(t, scanned.Scan("2007"))
+	assert.Equal(t, Year(2007), scanned)
+	assert.Nil(t, scanned.Scan(time.Date(2008, time.November, 30, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, Year(2008), scanned)
+	assert.Nil(t, scanned.Scan(nil))
+	assert.Equal(t, Year(0), scanned)
+
+	v, err := Year(2007).Value()
+	assert.Nil(t, err)
+	assert.Equal(t, driver.Value("2007"), v)
+}
@@ -0,0 +1,105 @@
+package datetime
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+	"unicode"
+)
+
+// Decoder reads a series of timestamps from an io.Reader, like a log with one timestamp per line.
+// Timestamps are separated by a delimiter byte.  Whitespace around each one, including a '\r' before
+// a '\n', is ignored, and empty ones are skipped, so a trailing delimiter is fine.  The delimiter
+// can't be a byte that appears in timestamps, so ',' can't be used when fractions may be written with
+// a decimal comma, like 10:10:10,5.
+type Decoder struct {
+	r     *bufio.Reader
+	delim byte
+	p     *Parser
+
+	rec []byte                  // the timestamp being parsed, reused between calls
+	in  bytes.Reader            // reads rec for s
+	s   *scanner                // reused by the general parser
+	pos struct{ line, col int } // where the next timestamp starts
+}
+
+// NewDecoder returns a Decoder that reads timestamps separated by delim from r, and parses them with
+// the given options.
+func NewDecoder(r io.Reader, delim byte, opts ...Option) *Decoder {
+	d := &Decoder{r: bufio.NewReader(r), delim: delim, p: NewParser(opts...)}
+	d.s = newScanner(&d.in)
+	d.pos.line, d.pos.col = 1, 1
+	return d
+}
+
+// Decode reads and parses the next timestamp.  It returns io.EOF when there are no more.  Parsing
+// errors are *ParseError values with the Line and Column set, and the Decoder can carry on to the
+// next timestamp after one.  Errors from the io.Reader are returned as they are.
+func (d *Decoder) Decode() (time.Time, error) {
+	for {
+		line, col := d.pos.line, d.pos.col
+		rec, err := d.readRecord()
+		if err != nil && err != io.EOF {
+			return zeroTime, err
+		}
+		trimmed := bytes.TrimLeftFunc(rec, unicode.IsSpace)
+		line, col = advance(line, col, rec[:len(rec)-len(trimmed)])
+		if rec = bytes.TrimRightFunc(trimmed, unicode.IsSpace); len(rec) > 0 {
+			return d.parse(rec, line, col)
+		}
+		if err == io.EOF {
+			return zeroTime, io.EOF
+		}
+	}
+}
+
+// readRecord reads up to and including the next delimiter, and returns what was before it.
+func (d *Decoder) readRecord() ([]byte, error) {
+	d.rec = d.rec[:0]
+	for {
+		b, err := d.r.ReadSlice(d.delim)
+		d.rec = append(d.rec, b...)
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		d.pos.line, d.pos.col = advance(d.pos.line, d.pos.col, d.rec)
+
+		rec := d.rec
+		if n := len(rec); n > 0 && rec[n-1] == d.delim {
+			rec = rec[:n-1]
+		}
+		return rec, err
+	}
+}
+
+// parse parses one timestamp that started at the given line and column.
+func (d *Decoder) parse(rec []byte, line, col int) (time.Time, error) {
+	if t, _, ok := d.p.parseFast(rec); ok {
+		return t, nil
+	}
+
+	d.in.Reset(rec)
+	d.s.reset(&d.in)
+	p := parser{s: d.s, input: rec, opts: d.p.opts}
+	t, err := p.parse()
+	if perr, ok := err.(*ParseError); ok {
+		perr.Line, perr.Column = advance(line, col, rec[:perr.Offset])
+		perr.msg = fmt.Sprintf("line %d, column %d: %s", perr.Line, perr.Column, perr.msg)
+	}
+	return t, err
+}
+
+// advance returns the line and column after reading b from the given line and column.  Columns count
+// bytes.
+func advance(line, col int, b []byte) (int, int) {
+	for _, c := range b {
+		if c == '\n' {
+			line, col = line+1, 1
+		} else {
+			col++
+		}
+	}
+	return line, col
+}
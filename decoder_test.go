@@ -0,0 +1,151 @@
+package datetime
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBytes(t *testing.T) {
+	ts, err := ParseBytes([]byte("2007-11-30T10:10:10Z"), time.Local)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC), ts)
+
+	ts, err = ParseBytes([]byte("2007-11-30T10:10"), time.UTC)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2007, time.November, 30, 10, 10, 0, 0, time.UTC), ts)
+
+	ts, err = ParseBytes([]byte("2007-02-29"), time.UTC, Lenient())
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2007, time.March, 1, 0, 0, 0, 0, time.UTC), ts)
+
+	ts, err = ParseBytes([]byte("2007-11-30T10:10:10"), time.UTC, RequireOffset())
+	assert.Equal(t, zeroTime, ts)
+	assert.EqualError(t, err, "found , expected Z or timezone offset")
+
+	ts, err = NewParser(RFC3339()).ParseBytes([]byte("2007-11-30 10:10:10z"))
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC), ts)
+}
+
+func TestDecoder(t *testing.T) {
+	input := "2007-11-30T10:10:10Z\r\n" +
+		"2007-11-30T10:10\n" +
+		"\n" +
+		"20071130T101010+0100\n" +
+		"2007-11-30T10:10:10.5Z\n"
+	d := NewDecoder(strings.NewReader(input), '\n')
+
+	var got []time.Time
+	for {
+		ts, err := d.Decode()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		got = append(got, ts)
+	}
+	assert.Equal(t, []time.Time{
+		time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC),
+		time.Date(2007, time.November, 30, 10, 10, 0, 0, time.UTC),
+		time.Date(2007, time.November, 30, 10, 10, 10, 0, time.FixedZone("+0100", 60*60)),
+		time.Date(2007, time.November, 30, 10, 10, 10, 500000000, time.UTC),
+	}, got)
+
+	// io.EOF keeps being returned at the end.
+	_, err := d.Decode()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestDecoderDelimiter(t *testing.T) {
+	// the last timestamp doesn't need a delimiter after it.
+	d := NewDecoder(strings.NewReader("2007-11-30,2007-12-01T10:10,2008"), ',', DefaultLocation(time.Local))
+	for _, want := range []time.Time{
+		time.Date(2007, time.November, 30, 0, 0, 0, 0, time.Local),
+		time.Date(2007, time.December, 1, 10, 10, 0, 0, time.Local),
+		time.Date(2008, time.January, 1, 0, 0, 0, 0, time.Local),
+	} {
+		ts, err := d.Decode()
+		assert.Nil(t, err)
+		assert.Equal(t, want, ts)
+	}
+	_, err := d.Decode()
+	assert.Equal(t, io.EOF, err)
+
+	// whitespace around timestamps is ignored, and columns in errors skip it.
+	d = NewDecoder(strings.NewReader("2007-11-30,\n2008, \t,  2007-13"), ',')
+	for _, want := range []time.Time{
+		time.Date(2007, time.November, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, time.January, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		ts, err := d.Decode()
+		assert.Nil(t, err)
+		assert.Equal(t, want, ts)
+	}
+	_, err = d.Decode()
+	assert.EqualError(t, err, "line 2, column 16: 13 is not a valid month")
+	_, err = d.Decode()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestDecoderErrors(t *testing.T) {
+	input := "2007-11-30T10:10:10Z\n" +
+		"2007-13-30\n" +
+		"2007-11-30T10:10:10Z\n" +
+		"2007-11-30TA\n"
+	d := NewDecoder(strings.NewReader(input), '\n')
+
+	_, err := d.Decode()
+	assert.Nil(t, err)
+
+	// the Decoder carries on after a bad timestamp.
+	ts, err := d.Decode()
+	assert.Equal(t, zeroTime, ts)
+	assert.EqualError(t, err, "line 2, column 6: 13 is not a valid month")
+	var perr *ParseError
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, "2007-13-30", perr.Input)
+	assert.Equal(t, 5, perr.Offset)
+	assert.Equal(t, 2, perr.Line)
+	assert.Equal(t, 6, perr.Column)
+
+	_, err = d.Decode()
+	assert.Nil(t, err)
+
+	_, err = d.Decode()
+	assert.EqualError(t, err, "line 4, column 12: expected number. got A")
+
+	// columns carry on along a line when the delimiter isn't a newline, and a timestamp can span lines.
+	d = NewDecoder(strings.NewReader("2007-11-30;2007-11-3Q\n2007;2007-02-29"), ';')
+	_, err = d.Decode()
+	assert.Nil(t, err)
+	_, err = d.Decode()
+	assert.EqualError(t, err, "line 1, column 21: found Q, expected T or EOF")
+	_, err = d.Decode()
+	assert.EqualError(t, err, "line 2, column 14: 29 is not a valid day in February 2007")
+}
+
+func TestDecoderLongInput(t *testing.T) {
+	// timestamps longer than the read buffer are put back together.
+	long := "2007-11-30T10:10:10." + strings.Repeat("0", 2*4096) + "1Z"
+	d := NewDecoder(iotest.OneByteReader(strings.NewReader(long+"\n2008\n")), '\n')
+	ts, err := d.Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC), ts)
+
+	ts, err = d.Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2008, time.January, 1, 0, 0, 0, 0, time.UTC), ts)
+}
+
+func TestDecoderReadError(t *testing.T) {
+	boom := errors.New("boom")
+	d := NewDecoder(iotest.ErrReader(boom), '\n')
+	_, err := d.Decode()
+	assert.Equal(t, boom, err)
+}
@@ -0,0 +1,317 @@
+package datetime
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration is an ISO 8601 duration like P3Y6M4DT12H30M5S.  Unlike a time.Duration, it keeps the
+// calendar components (years, months, weeks, and days) separate from the exact hours, minutes, and
+// seconds, since the length of a month or day depends on when it's applied.
+//
+// All components are non-negative.  A negative duration like -P1D is represented by setting Negative.
+type Duration struct {
+	Negative bool
+	Years    int
+	Months   int
+	Weeks    int
+	Days     int
+	// Clock is the exact hours, minutes, and seconds part of the duration.
+	Clock time.Duration
+}
+
+// ParseDuration takes a string with an ISO 8601 duration in it, like P3Y6M4DT12H30M5S, PT0.5S, P2W,
+// or -P1D, and returns a Duration.  Only the hours, minutes, and seconds components may have a
+// decimal fraction, and only on the last component given.
+func ParseDuration(s string) (Duration, error) { return parseDurationBytes([]byte(s)) }
+
+// AddTo returns t with the duration applied.  The calendar components are added first, and then the
+// clock component.  Unlike time.Time.AddDate, adding years and months keeps to the last day of a
+// shorter month instead of rolling over into the next one, so January 31st plus P1M is February 28th
+// or 29th.
+func (d Duration) AddTo(t time.Time) time.Time {
+	sign := 1
+	if d.Negative {
+		sign = -1
+	}
+	t = addDate(t, sign*d.Years, sign*d.Months, sign*(d.Weeks*7+d.Days))
+	return t.Add(time.Duration(sign) * d.Clock)
+}
+
+// subtractFrom returns t with the duration taken away, undoing AddTo.  The clock component is
+// subtracted first, and then the calendar components.
+func (d Duration) subtractFrom(t time.Time) time.Time {
+	sign := 1
+	if d.Negative {
+		sign = -1
+	}
+	t = t.Add(-time.Duration(sign) * d.Clock)
+	return addDate(t, -sign*d.Years, -sign*d.Months, -sign*(d.Weeks*7+d.Days))
+}
+
+// addDate is time.Time.AddDate, except that when years or months are added, the day is clamped to the
+// last day of the month they land on before the days are added.
+func addDate(t time.Time, years, months, days int) time.Time {
+	year, month, day := t.Date()
+	if years != 0 || months != 0 {
+		// let time.Date normalize the month, from the first so that the day can't roll it over.
+		first := time.Date(year+years, month+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+		year, month = first.Year(), first.Month()
+		if last := daysIn(month, year); day > last {
+			day = last
+		}
+	}
+	hour, min, sec := t.Clock()
+	return time.Date(year, month, day+days, hour, min, sec, t.Nanosecond(), t.Location())
+}
+
+// times returns the duration with each of its components multiplied by n.
+func (d Duration) times(n int) Duration {
+	return Duration{
+		Negative: d.Negative,
+		Years:    d.Years * n,
+		Months:   d.Months * n,
+		Weeks:    d.Weeks * n,
+		Days:     d.Days * n,
+		Clock:    d.Clock * time.Duration(n),
+	}
+}
+
+// approximate returns the duration's length as a time.Duration, using average lengths for years and
+// months and ignoring daylight saving time.
+func (d Duration) approximate() time.Duration {
+	const day = 24 * time.Hour
+	out := time.Duration(d.Years)*36524*day/100 +
+		time.Duration(d.Months)*36524*day/1200 +
+		time.Duration(d.Weeks*7+d.Days)*day +
+		d.Clock
+	if d.Negative {
+		return -out
+	}
+	return out
+}
+
+// IsZero returns whether every component of the duration is zero.
+func (d Duration) IsZero() bool {
+	return d.Years == 0 && d.Months == 0 && d.Weeks == 0 && d.Days == 0 && d.Clock == 0
+}
+
+// String returns the duration's ISO 8601 representation, like P3Y6M4DT12H30M5S.  A zero duration is
+// represented as PT0S.
+func (d Duration) String() string {
+	if d.IsZero() {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+	if d.Negative {
+		b.WriteByte('-')
+	}
+	b.WriteByte('P')
+	writeComponent(&b, d.Years, 'Y')
+	writeComponent(&b, d.Months, 'M')
+	writeComponent(&b, d.Weeks, 'W')
+	writeComponent(&b, d.Days, 'D')
+
+	if d.Clock != 0 {
+		b.WriteByte('T')
+		hours := d.Clock / time.Hour
+		minutes := (d.Clock % time.Hour) / time.Minute
+		nsecs := d.Clock % time.Minute
+		writeComponent(&b, int(hours), 'H')
+		writeComponent(&b, int(minutes), 'M')
+		if nsecs != 0 {
+			b.WriteString(strconv.FormatInt(int64(nsecs/time.Second), 10))
+			if frac := nsecs % time.Second; frac != 0 {
+				b.WriteByte('.')
+				b.WriteString(strings.TrimRight(fmt.Sprintf("%09d", frac), "0"))
+			}
+			b.WriteByte('S')
+		}
+	}
+	return b.String()
+}
+
+func writeComponent(b *strings.Builder, n int, designator byte) {
+	if n != 0 {
+		b.WriteString(strconv.Itoa(n))
+		b.WriteByte(designator)
+	}
+}
+
+// MarshalText implements the encoding TextMarshaler interface.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding TextUnmarshaler interface.
+func (d *Duration) UnmarshalText(data []byte) error {
+	parsed, err := parseDurationBytes(data)
+	*d = parsed
+	return err
+}
+
+// MarshalJSON implements the JSON Marshaler interface, writing the duration as a JSON string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the JSON Unmarshaler interface, allowing datetime.Duration struct fields
+// to be read from JSON string fields.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	// Ignore null, like in the main JSON package.
+	if string(data) == "null" {
+		*d = Duration{}
+		return nil
+	}
+	trimmed, err := trimQuotes(data)
+	if err != nil {
+		*d = Duration{}
+		return err
+	}
+	return d.UnmarshalText(trimmed)
+}
+
+// Scan implements the sql Scanner interface, allowing datetime.Duration fields to be read from
+// database columns.
+func (d *Duration) Scan(value interface{}) error {
+	b, err := scanBytes(value)
+	if err != nil {
+		*d = Duration{}
+		return err
+	}
+	return d.UnmarshalText(b)
+}
+
+// Value implements the sql Valuer interface, allowing datetime.Duration fields to be saved to
+// database columns.
+func (d Duration) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func parseDurationBytes(b []byte) (Duration, error) {
+	p := newParser(b, options{})
+	return p.parseDuration()
+}
+
+// dateDesignators and timeDesignators list the designators allowed in each part of a duration, in
+// the order they must appear.
+var (
+	dateDesignators = []token{Y, M, W, D}
+	timeDesignators = []token{H, M, S}
+)
+
+// clockUnits is the size of each of the time part's designators.
+var clockUnits = map[token]time.Duration{
+	H: time.Hour,
+	M: time.Minute,
+	S: time.Second,
+}
+
+func (p *parser) parseDuration() (Duration, error) {
+	var d Duration
+	parseErr := func(err error) (Duration, error) {
+		return Duration{}, err
+	}
+
+	tok, lit := p.scan()
+	if tok == DASH {
+		d.Negative = true
+		tok, lit = p.scan()
+	}
+	if tok != P {
+		return parseErr(p.unexpected("P"))
+	}
+
+	inTime := false
+	// next is the index of the earliest designator still allowed in the current part, and count is
+	// the number of components seen in the current part.
+	next, count := 0, 0
+	fractional := false
+	for {
+		tok, lit = p.scan()
+		switch tok {
+		case EOF:
+			if count == 0 {
+				return parseErr(p.unexpected("number"))
+			}
+			return d, nil
+		case T:
+			if inTime || fractional || lit != "T" {
+				return parseErr(p.unexpected("number", "EOF"))
+			}
+			inTime = true
+			next, count = 0, 0
+			continue
+		case NUMBER:
+			if fractional {
+				return parseErr(p.unexpected("EOF"))
+			}
+		default:
+			return parseErr(p.unexpected("number"))
+		}
+		whole, wholeField := lit, p.last(0, -1)
+
+		var sign, frac string
+		tok, lit = p.scan()
+		if isDecimalSign(tok) {
+			sign = lit
+			tok, lit = p.scan()
+			if tok != NUMBER {
+				return parseErr(p.syntaxError([]string{"number"}, "expected fraction. got %s", lit))
+			}
+			frac = lit
+			fractional = true
+			tok, lit = p.scan()
+		}
+
+		// find the designator, which must come after any that have already been used.
+		designators := dateDesignators
+		if inTime {
+			designators = timeDesignators
+		}
+		found := false
+		for i := next; i < len(designators); i++ {
+			if designators[i] == tok {
+				next, found = i+1, true
+				break
+			}
+		}
+		if !found {
+			return parseErr(p.unexpected("duration designator"))
+		}
+		count++
+
+		if !inTime {
+			if frac != "" {
+				return parseErr(p.errorAt(UnsupportedError, wholeField, nil, "%s%s%s%s: only hours, minutes, and seconds may have a fraction", whole, sign, frac, lit))
+			}
+			n, err := strconv.Atoi(whole)
+			if err != nil {
+				return parseErr(p.errorAt(RangeError, wholeField, nil, "%s%s is out of range", whole, lit))
+			}
+			switch tok {
+			case Y:
+				d.Years = n
+			case M:
+				d.Months = n
+			case W:
+				d.Weeks = n
+			case D:
+				d.Days = n
+			}
+			continue
+		}
+
+		unit := int64(clockUnits[tok])
+		n, err := strconv.ParseInt(whole, 10, 64)
+		if err != nil || n > (math.MaxInt64-int64(d.Clock))/unit-1 {
+			return parseErr(p.errorAt(RangeError, wholeField, nil, "%s%s is out of range", whole, lit))
+		}
+		d.Clock += time.Duration(n*unit + fractionOf(frac, unit))
+	}
+}
@@ -0,0 +1,221 @@
+package datetime
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDuration(t *testing.T) {
+	tt := []struct {
+		input  string
+		output Duration
+	}{
+		{
+			input:  "P3Y6M4DT12H30M5S",
+			output: Duration{Years: 3, Months: 6, Days: 4, Clock: 12*time.Hour + 30*time.Minute + 5*time.Second},
+		},
+		{
+			input:  "PT0.5S",
+			output: Duration{Clock: 500 * time.Millisecond},
+		},
+		{
+			input:  "PT0,5S",
+			output: Duration{Clock: 500 * time.Millisecond},
+		},
+		{
+			input:  "P2W",
+			output: Duration{Weeks: 2},
+		},
+		{
+			input:  "-P1D",
+			output: Duration{Negative: true, Days: 1},
+		},
+		{
+			input:  "P1M",
+			output: Duration{Months: 1},
+		},
+		{
+			input:  "PT1M",
+			output: Duration{Clock: time.Minute},
+		},
+		{
+			input:  "PT1.5H",
+			output: Duration{Clock: 90 * time.Minute},
+		},
+		{
+			input:  "PT36H",
+			output: Duration{Clock: 36 * time.Hour},
+		},
+		{
+			input:  "PT0.000000001S",
+			output: Duration{Clock: 1},
+		},
+		{
+			input:  "PT0S",
+			output: Duration{},
+		},
+	}
+
+	for _, tc := range tt {
+		d, err := ParseDuration(tc.input)
+		assert.Nil(t, err, tc.input)
+		assert.Equal(t, tc.output, d, tc.input)
+	}
+}
+
+func TestParseDurationErrors(t *testing.T) {
+	tt := []struct {
+		input string
+		err   string
+	}{
+		{
+			input: "",
+			err:   "found , expected P",
+		},
+		{
+			input: "P",
+			err:   "found , expected number",
+		},
+		{
+			input: "P1DT",
+			err:   "found , expected number",
+		},
+		{
+			input: "P1D1Y",
+			err:   "found Y, expected duration designator",
+		},
+		{
+			input: "P1Q",
+			err:   "found Q, expected duration designator",
+		},
+		{
+			input: "P1H",
+			err:   "found H, expected duration designator",
+		},
+		{
+			input: "PT1.5H30M",
+			err:   "found 30, expected EOF",
+		},
+		{
+			input: "P1.5Y",
+			err:   "1.5Y: only hours, minutes, and seconds may have a fraction",
+		},
+		{
+			input: "P1,5D",
+			err:   "1,5D: only hours, minutes, and seconds may have a fraction",
+		},
+		{
+			input: "PT1.S",
+			err:   "expected fraction. got S",
+		},
+		{
+			input: "P1DT1HT1M",
+			err:   "found T, expected number or EOF",
+		},
+		{
+			input: "P99999999999999999999Y",
+			err:   "99999999999999999999Y is out of range",
+		},
+		{
+			input: "PT9999999999H",
+			err:   "9999999999H is out of range",
+		},
+	}
+
+	for _, tc := range tt {
+		d, err := ParseDuration(tc.input)
+		assert.Equal(t, Duration{}, d, tc.input)
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+}
+
+func TestDurationAddTo(t *testing.T) {
+	start := time.Date(2007, time.January, 31, 10, 0, 0, 0, time.UTC)
+	tt := []struct {
+		input  string
+		output time.Time
+	}{
+		{
+			input:  "P1M",
+			output: time.Date(2007, time.February, 28, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "P1Y1M",
+			output: time.Date(2008, time.February, 29, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			// the day is clamped before the days are added.
+			input:  "P1M1D",
+			output: time.Date(2007, time.March, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "-P2M",
+			output: time.Date(2006, time.November, 30, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "P1Y2M10DT2H30M",
+			output: time.Date(2008, time.April, 10, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			input:  "P2W",
+			output: time.Date(2007, time.February, 14, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "-P1DT1H",
+			output: time.Date(2007, time.January, 30, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tt {
+		d, err := ParseDuration(tc.input)
+		assert.Nil(t, err, tc.input)
+		assert.Equal(t, tc.output, d.AddTo(start), tc.input)
+	}
+}
+
+func TestDurationString(t *testing.T) {
+	for _, s := range []string{"P3Y6M4DT12H30M5S", "PT0.5S", "P2W", "-P1D", "PT1M", "P1MT1M", "PT36H", "PT0S"} {
+		d, err := ParseDuration(s)
+		assert.Nil(t, err, s)
+		assert.Equal(t, s, d.String())
+	}
+}
+
+func TestDurationJSON(t *testing.T) {
+	type wrapper struct {
+		D Duration `json:"d"`
+	}
+
+	out, err := json.Marshal(wrapper{D: Duration{Days: 4, Clock: 90 * time.Minute}})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"d":"P4DT1H30M"}`, string(out))
+
+	var w wrapper
+	assert.Nil(t, json.Unmarshal(out, &w))
+	assert.Equal(t, Duration{Days: 4, Clock: 90 * time.Minute}, w.D)
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"d":null}`), &w))
+	assert.Equal(t, Duration{}, w.D)
+
+	err = json.Unmarshal([]byte(`{"d":1}`), &w)
+	assert.EqualError(t, err, "1 does not begin and end with double quotes")
+}
+
+func TestDurationScanValue(t *testing.T) {
+	var d Duration
+	assert.Nil(t, d.Scan([]byte("P1D")))
+	assert.Equal(t, Duration{Days: 1}, d)
+
+	assert.Nil(t, d.Scan("PT1S"))
+	assert.Equal(t, Duration{Clock: time.Second}, d)
+
+	assert.EqualError(t, d.Scan(1), "can only scan string and []byte, not int")
+	assert.Equal(t, Duration{}, d)
+
+	val, err := Duration{Years: 1}.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "P1Y", val)
+}
@@ -0,0 +1,93 @@
+package datetime
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorCategory says what kind of problem caused a ParseError.
+type ErrorCategory int
+
+const (
+	// SyntaxError means the input had a character or token where a different one was expected.
+	SyntaxError ErrorCategory = iota + 1
+	// RangeError means a field like the month or hour was well formed, but out of range.
+	RangeError
+	// UnsupportedError means the input was in a format this package doesn't support, like a number
+	// with the wrong count of digits.
+	UnsupportedError
+)
+
+// String returns the category's name.
+func (c ErrorCategory) String() string {
+	switch c {
+	case SyntaxError:
+		return "syntax"
+	case RangeError:
+		return "range"
+	case UnsupportedError:
+		return "unsupported"
+	default:
+		return fmt.Sprintf("ErrorCategory(%d)", int(c))
+	}
+}
+
+// ParseError describes a problem with a timestamp that couldn't be parsed.  Use errors.As to get one
+// from the errors returned by this package.
+type ParseError struct {
+	// Input is the full string that was being parsed.
+	Input string
+	// Offset is the byte offset in Input where the problem was found.
+	Offset int
+	// Literal is the text at Offset that caused the problem.  It's empty at the end of the input.
+	Literal string
+	// Expected lists what would have been allowed instead of Literal, for syntax errors.
+	Expected []string
+	// Category says what kind of problem this is.
+	Category ErrorCategory
+	// Line and Column are where the problem was found in a Decoder's input, both counting from 1.
+	// They're 0 for errors from anything else.
+	Line, Column int
+
+	msg string
+}
+
+// Error returns a description of the problem.
+func (e *ParseError) Error() string { return e.msg }
+
+// inputError returns a ParseError for a problem that's found outside the parser, like in the
+// structure of an interval.
+func inputError(input string, offset int, lit string, expected []string, category ErrorCategory, format string, args ...interface{}) error {
+	return &ParseError{
+		Input:    input,
+		Offset:   offset,
+		Literal:  lit,
+		Expected: expected,
+		Category: category,
+		msg:      fmt.Sprintf(format, args...),
+	}
+}
+
+// withinInput moves a ParseError from parsing part of input, which starts at offset, to input as a
+// whole.  Other errors are returned as they are.
+func withinInput(err error, input string, offset int) error {
+	if perr, ok := err.(*ParseError); ok {
+		perr.Input = input
+		perr.Offset += offset
+	}
+	return err
+}
+
+// orList joins the items into a list like "a, b, or c".
+func orList(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " or " + items[1]
+	default:
+		return strings.Join(items[:len(items)-1], ", ") + ", or " + items[len(items)-1]
+	}
+}
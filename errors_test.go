@@ -0,0 +1,217 @@
+package datetime
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError(t *testing.T) {
+	tt := []struct {
+		input string
+		err   ParseError
+	}{
+		{
+			input: "2007-11-30TA",
+			err: ParseError{
+				Input:    "2007-11-30TA",
+				Offset:   11,
+				Literal:  "A",
+				Expected: []string{"number"},
+				Category: SyntaxError,
+			},
+		},
+		{
+			input: "2007-",
+			err: ParseError{
+				Input:    "2007-",
+				Offset:   5,
+				Expected: []string{"number"},
+				Category: SyntaxError,
+			},
+		},
+		{
+			input: "2007Q",
+			err: ParseError{
+				Input:    "2007Q",
+				Offset:   4,
+				Literal:  "Q",
+				Expected: []string{"dash", "W", "T", "EOF"},
+				Category: SyntaxError,
+			},
+		},
+		{
+			input: "2007-13",
+			err: ParseError{
+				Input:    "2007-13",
+				Offset:   5,
+				Literal:  "13",
+				Category: RangeError,
+			},
+		},
+		{
+			input: "20071131",
+			err: ParseError{
+				Input:    "20071131",
+				Offset:   6,
+				Literal:  "31",
+				Category: RangeError,
+			},
+		},
+		{
+			input: "2007-11-30T10:10:70",
+			err: ParseError{
+				Input:    "2007-11-30T10:10:70",
+				Offset:   17,
+				Literal:  "70",
+				Category: RangeError,
+			},
+		},
+		{
+			input: "2007-11-30T106010",
+			err: ParseError{
+				Input:    "2007-11-30T106010",
+				Offset:   13,
+				Literal:  "60",
+				Category: RangeError,
+			},
+		},
+		{
+			input: "2009W538",
+			err: ParseError{
+				Input:    "2009W538",
+				Offset:   7,
+				Literal:  "8",
+				Category: RangeError,
+			},
+		},
+		{
+			input: "20077",
+			err: ParseError{
+				Input:    "20077",
+				Literal:  "20077",
+				Expected: []string{"yyyy-mm-dd", "yyyymmdd", "yyyy-ddd", "yyyyddd"},
+				Category: UnsupportedError,
+			},
+		},
+		{
+			input: "2007-11-30T10:10:10+111",
+			err: ParseError{
+				Input:    "2007-11-30T10:10:10+111",
+				Offset:   20,
+				Literal:  "111",
+				Expected: []string{"hh:mm:ss", "hh:mm", "hhmmss", "hhmm", "hh"},
+				Category: UnsupportedError,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		_, err := ParseUTC(tc.input)
+		var perr *ParseError
+		if assert.True(t, errors.As(err, &perr), tc.input) {
+			tc.err.msg = err.Error()
+			assert.Equal(t, tc.err, *perr, tc.input)
+		}
+	}
+}
+
+func TestParseErrorFromTypes(t *testing.T) {
+	var perr *ParseError
+
+	_, err := JSONParse([]byte(`"2007-13"`), time.UTC)
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, `"2007-13"`, perr.Input)
+	assert.Equal(t, 6, perr.Offset)
+	assert.Equal(t, RangeError, perr.Category)
+
+	var dt DefaultUTC
+	err = json.Unmarshal([]byte(`2007`), &dt)
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, SyntaxError, perr.Category)
+	assert.Equal(t, []string{`"`}, perr.Expected)
+
+	var dl DefaultLocal
+	err = dl.Scan("2007-11-31")
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, "31", perr.Literal)
+	assert.Equal(t, 8, perr.Offset)
+
+	err = dl.Scan(2007)
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, UnsupportedError, perr.Category)
+
+	var d Duration
+	err = d.UnmarshalText([]byte("P1Q"))
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, 2, perr.Offset)
+	assert.Equal(t, []string{"duration designator"}, perr.Expected)
+}
+
+func TestParseErrorFromIntervals(t *testing.T) {
+	interval := func(s string) error {
+		_, err := ParseInterval(s, time.UTC)
+		return err
+	}
+	recurrence := func(s string) error {
+		_, err := ParseRecurrence(s, time.UTC)
+		return err
+	}
+
+	tt := []struct {
+		input    string
+		parse    func(string) error
+		offset   int
+		literal  string
+		category ErrorCategory
+	}{
+		{input: "2007-03-01", parse: interval, offset: 10, category: SyntaxError},
+		{input: "2007/2008/2009", parse: interval, offset: 9, literal: "/", category: SyntaxError},
+		{input: "P1D/P2D", parse: interval, offset: 0, literal: "P1D", category: SyntaxError},
+		{input: "2008-05-11/2007-03-01", parse: interval, offset: 11, literal: "2007-03-01", category: RangeError},
+		{input: "2007-13/P1D", parse: interval, offset: 5, literal: "13", category: RangeError},
+		{input: "2007-03-01/P1Q", parse: interval, offset: 13, literal: "Q", category: SyntaxError},
+		{input: "2007-03-01/2007-13", parse: interval, offset: 16, literal: "13", category: RangeError},
+		{input: "2007-12-14T13:30/15:61", parse: interval, offset: 20, literal: "61", category: RangeError},
+		{input: "2008-03-01/P1D", parse: recurrence, offset: 0, literal: "2008-03-01", category: SyntaxError},
+		{input: "R5", parse: recurrence, offset: 2, category: SyntaxError},
+		{input: "Rx/2008-03-01/P1D", parse: recurrence, offset: 1, literal: "x", category: SyntaxError},
+		{input: "R-1/2008-03-01/P1D", parse: recurrence, offset: 1, literal: "-1", category: RangeError},
+		{input: "R/P1D/2008-03-01", parse: recurrence, offset: 2, literal: "P1D", category: SyntaxError},
+		{input: "R/2008-03-01/PT0S", parse: recurrence, offset: 13, literal: "PT0S", category: RangeError},
+		{input: "R/2008-03-01/-P1D", parse: recurrence, offset: 13, literal: "-P1D", category: RangeError},
+		{input: "R/2008-13/P1D", parse: recurrence, offset: 7, literal: "13", category: RangeError},
+	}
+
+	for _, tc := range tt {
+		err := tc.parse(tc.input)
+		var perr *ParseError
+		if assert.True(t, errors.As(err, &perr), tc.input) {
+			assert.Equal(t, tc.input, perr.Input, tc.input)
+			assert.Equal(t, tc.offset, perr.Offset, tc.input)
+			assert.Equal(t, tc.literal, perr.Literal, tc.input)
+			assert.Equal(t, tc.category, perr.Category, tc.input)
+		}
+	}
+
+	var i Interval
+	err := i.UnmarshalJSON([]byte(`"2007-13/P1D"`))
+	var perr *ParseError
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, `"2007-13/P1D"`, perr.Input)
+	assert.Equal(t, 6, perr.Offset)
+
+	var r Recurrence
+	err = r.Scan("R/P1D/2008-03-01")
+	assert.True(t, errors.As(err, &perr))
+}
+
+func TestErrorCategoryString(t *testing.T) {
+	assert.Equal(t, "syntax", SyntaxError.String())
+	assert.Equal(t, "range", RangeError.String())
+	assert.Equal(t, "unsupported", UnsupportedError.String())
+	assert.Equal(t, "ErrorCategory(0)", ErrorCategory(0).String())
+}
@@ -0,0 +1,173 @@
+package datetime
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// parseFast parses the most common timestamp shapes, yyyy-mm-dd and yyyy-mm-ddThh:mm:ss with up to
+// nine fraction digits and an optional Z or ±hh:mm offset, straight from the bytes without
+// allocating.  Anything else, including every input that would be an error, returns false so that
+// the general parser can handle it and report the error properly.  When it returns true, the result
+// and Details are the same as the general parser's.
+func (p *Parser) parseFast(b []byte) (time.Time, Details, bool) {
+	var d Details
+	// the RFC 3339 profile needs a time and an offset, and ignores the Separators option.
+	iso := p.opts.profile == profileISO8601
+	if !iso && p.opts.profile != profileRFC3339 {
+		return zeroTime, d, false
+	}
+
+	if len(b) < len("yyyy-mm-dd") || b[4] != '-' || b[7] != '-' {
+		return zeroTime, d, false
+	}
+	year, ok1 := digits(b[0:4])
+	month, ok2 := digits(b[5:7])
+	day, ok3 := digits(b[8:10])
+	if !(ok1 && ok2 && ok3) || !checkMonth(month) {
+		return zeroTime, d, false
+	}
+	if p.opts.lenient {
+		if !checkDay(time.Month(month), day) {
+			return zeroTime, d, false
+		}
+	} else if !checkYearMonthDay(year, time.Month(month), day) {
+		return zeroTime, d, false
+	}
+
+	if len(b) == len("yyyy-mm-dd") {
+		d.Precision, d.Zone = PrecisionDay, ZoneDefault
+		if !iso || p.opts.requireOffset || !p.opts.precision(d.Precision) {
+			return zeroTime, d, false
+		}
+		return time.Date(year, time.Month(month), day, 0, 0, 0, 0, p.opts.location), d, true
+	}
+
+	if len(b) < len("yyyy-mm-ddThh:mm:ss") || b[10] != 'T' || (iso && !p.opts.separator("T")) ||
+		b[13] != ':' || b[16] != ':' {
+		return zeroTime, d, false
+	}
+	hour, ok4 := digits(b[11:13])
+	min, ok5 := digits(b[14:16])
+	sec, ok6 := digits(b[17:19])
+	if !(ok4 && ok5 && ok6) {
+		return zeroTime, d, false
+	}
+
+	// out of range fields, including 24:00 and leap seconds, are left to the general parser.
+	if !checkHour(hour) || !checkMinSec(min) || !checkMinSec(sec) {
+		return zeroTime, d, false
+	}
+
+	i := 19
+	var nsec int
+	d.Precision = PrecisionSecond
+	if i < len(b) && b[i] == '.' {
+		i++
+		start := i
+		for i < len(b) && i-start < 10 && isDigit(b[i]) {
+			nsec = nsec*10 + int(b[i]-'0')
+			i++
+		}
+		// more than nine digits need rounding, which the general parser does.
+		n := i - start
+		if n == 0 || n > 9 {
+			return zeroTime, d, false
+		}
+		for j := n; j < 9; j++ {
+			nsec *= 10
+		}
+		d.Precision = PrecisionSubsecond
+		d.FractionDigits = n
+	}
+	if !p.opts.precision(d.Precision) {
+		return zeroTime, d, false
+	}
+
+	loc := p.opts.location
+	d.Zone = ZoneDefault
+	if i == len(b) {
+		if !iso || p.opts.requireOffset {
+			return zeroTime, d, false
+		}
+	} else {
+		var ok bool
+		if loc, ok = p.fastLocation(b[i:]); !ok {
+			return zeroTime, d, false
+		}
+		d.Zone = ZoneOffset
+		if loc == time.UTC {
+			d.Zone = ZoneUTC
+		}
+	}
+	return time.Date(year, time.Month(month), day, hour, min, sec, 0, loc).Add(time.Duration(nsec)), d, true
+}
+
+// fastLocation reads a Z or ±hh:mm offset that must be the rest of the input.  -00:00 is left to the
+// general parser, since what it means depends on the profile.
+func (p *Parser) fastLocation(b []byte) (*time.Location, bool) {
+	if len(b) == 1 && b[0] == 'Z' {
+		return time.UTC, true
+	}
+	if len(b) != len("+hh:mm") || (b[0] != '+' && b[0] != '-') || b[3] != ':' {
+		return nil, false
+	}
+	hours, ok1 := digits(b[1:3])
+	minutes, ok2 := digits(b[4:6])
+	if !ok1 || !ok2 || !checkMinSec(minutes) || (b[0] == '-' && hours == 0 && minutes == 0) {
+		return nil, false
+	}
+	offset := hours*60*60 + minutes*60
+	if time.Duration(offset)*time.Second > p.opts.maxOffset {
+		return nil, false
+	}
+	if b[0] == '-' {
+		offset = -offset
+	}
+	return fixedZone(offset), true
+}
+
+// fixedZones caches the locations made by fixedZone, so that parsing offsets doesn't allocate a new
+// one every time.  There can be at most 2*99*60 of them.
+var fixedZones = struct {
+	sync.RWMutex
+	m map[int]*time.Location
+}{m: map[int]*time.Location{}}
+
+// fixedZone returns a location with the given offset in seconds east of UTC, which must be a whole
+// number of minutes.  It's named after its ±hh:mm offset, as the general parser names them.
+func fixedZone(offset int) *time.Location {
+	fixedZones.RLock()
+	loc, ok := fixedZones.m[offset]
+	fixedZones.RUnlock()
+	if ok {
+		return loc
+	}
+
+	sign, abs := '+', offset
+	if offset < 0 {
+		sign, abs = '-', -offset
+	}
+	loc = time.FixedZone(fmt.Sprintf("%c%02d:%02d", sign, abs/3600, abs%3600/60), offset)
+	fixedZones.Lock()
+	fixedZones.m[offset] = loc
+	fixedZones.Unlock()
+	return loc
+}
+
+// digits returns the value of b, and whether it was all digits.
+func digits(b []byte) (int, bool) {
+	var n int
+	for _, c := range b {
+		if !isDigit(c) {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,215 @@
+package datetime
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFast(t *testing.T) {
+	// the fast path must give exactly what the general parser does whenever it takes an input.
+	fast := []struct {
+		input string
+		opts  []Option
+	}{
+		{input: "2007-11-30T10:10:10Z"},
+		{input: "2007-11-30T10:10:10.1Z"},
+		{input: "2007-11-30T10:10:10.123456789Z"},
+		{input: "2007-11-30T10:10:10.000Z"},
+		{input: "2007-11-30T10:10:10+05:30"},
+		{input: "2007-11-30T10:10:10.5-08:00"},
+		{input: "2007-11-30T10:10:10+00:00"},
+		{input: "2007-11-30T10:10:10+18:00"},
+		{input: "2008-02-29T23:59:59Z"},
+		{input: "2007-02-29T10:10:10Z", opts: []Option{Lenient()}},
+		{input: "0000-01-01T00:00:00Z"},
+		{input: "2007-11-30T10:10:10"},
+		{input: "2007-11-30T10:10:10.5"},
+		{input: "2007-11-30T10:10:10", opts: []Option{DefaultLocation(time.Local)}},
+		{input: "2007-11-30T10:10:10", opts: []Option{DefaultLocation(time.FixedZone("EST", -5*60*60))}},
+		{input: "2007-11-30"},
+		{input: "2008-02-29", opts: []Option{DefaultLocation(time.Local)}},
+		{input: "2007-02-29", opts: []Option{Lenient()}},
+		{input: "2007-11-30", opts: []Option{Separators(' ')}},
+		{input: "2007-11-30T10:10:10Z", opts: []Option{RFC3339()}},
+		{input: "2007-11-30T10:10:10.25+01:00", opts: []Option{RFC3339()}},
+		{input: "2007-11-30T10:10:10Z", opts: []Option{RequireOffset()}},
+		{input: "2007-11-30T10:10:10Z", opts: []Option{ExpandedYears(2)}},
+		{input: "2007-11-30T10:10:10Z", opts: []Option{Precisions(PrecisionSecond)}},
+	}
+
+	for _, tc := range fast {
+		p := NewParser(tc.opts...)
+		ft, fd, ok := p.parseFast([]byte(tc.input))
+		assert.True(t, ok, tc.input)
+
+		pp := newParser([]byte(tc.input), p.opts)
+		gt, err := pp.parse()
+		assert.Nil(t, err, tc.input)
+		assert.Equal(t, gt, ft, tc.input)
+		assert.Equal(t, gt.Location().String(), ft.Location().String(), tc.input)
+		assert.Equal(t, pp.details, fd, tc.input)
+	}
+
+	// these are all left to the general parser, whether they're valid or not.
+	slow := []struct {
+		input string
+		opts  []Option
+	}{
+		{input: "2007-11-30T10:10"},
+		{input: "2007-11-30T10"},
+		{input: "2007-11"},
+		{input: "2007-11-30T"},
+		{input: "2007-11-30T10:10:10", opts: []Option{RequireOffset()}},
+		{input: "2007-11-30", opts: []Option{RequireOffset()}},
+		{input: "2007-11-30T10:10:10", opts: []Option{RFC3339()}},
+		{input: "2007-11-30", opts: []Option{RFC3339()}},
+		{input: "2007-11-30", opts: []Option{Precisions(PrecisionSecond)}},
+		{input: "2007-02-29"},
+		{input: "2007-11-31"},
+		{input: "2007-11-30T10:10:10."},
+		{input: "20071130T101010Z"},
+		{input: "2007-11-30t10:10:10Z"},
+		{input: "2007-11-30 10:10:10Z"},
+		{input: "2007-11-30T10:10:10z"},
+		{input: "2007-11-30T10:10:10,5Z"},
+		{input: "2007-11-30T10:10:10.Z"},
+		{input: "2007-11-30T10:10:10.1234567891Z"},
+		{input: "2007-11-30T10:10:10+0530"},
+		{input: "2007-11-30T10:10:10+05"},
+		{input: "2007-11-30T10:10:10+05:30:15"},
+		{input: "2007-11-30T10:10:10-00:00"},
+		{input: "2007-11-30T10:10:10+05:60"},
+		{input: "2007-11-30T10:10:10+19:00"},
+		{input: "2007-11-30T10:10:10+14:00", opts: []Option{MaxOffset(12 * time.Hour)}},
+		{input: "2007-11-30T10:10:10Z[Europe/Paris]"},
+		{input: "2007-11-30T10:10:10ZZ"},
+		{input: "2007-11-30T24:00:00Z"},
+		{input: "2007-11-30T23:59:60Z"},
+		{input: "2007-13-30T10:10:10Z"},
+		{input: "2007-02-29T10:10:10Z"},
+		{input: "2007-11-31T10:10:10Z"},
+		{input: "2007-11-3OT10:10:10Z"},
+		{input: "2007-11-30T10:10:10Z", opts: []Option{Separators(' ')}},
+		{input: "2007-11-30T10:10:10Z", opts: []Option{Precisions(PrecisionSubsecond)}},
+		{input: "2007-11-30T10:10:10Z", opts: []Option{XSDDateTime()}},
+		{input: "2007-11-30T10:10:10Z", opts: []Option{W3CDTF()}},
+	}
+
+	for _, tc := range slow {
+		_, _, ok := NewParser(tc.opts...).parseFast([]byte(tc.input))
+		assert.False(t, ok, tc.input)
+	}
+}
+
+func TestParseFastAllocs(t *testing.T) {
+	p := NewParser()
+	for _, input := range []string{
+		"2007-11-30T10:10:10.123456789Z",
+		"2007-11-30T10:10:10.123456789+05:30",
+		"2007-11-30T10:10:10",
+		"2007-11-30T10:10:10.5",
+		"2007-11-30",
+	} {
+		b := []byte(input)
+		p.ParseBytes(b) // fill the offset cache.
+		allocs := testing.AllocsPerRun(100, func() {
+			if _, err := p.ParseBytes(b); err != nil {
+				t.Fatal(err)
+			}
+		})
+		assert.Equal(t, 0.0, allocs, input)
+
+		// the package functions use the preconfigured parsers without options.
+		allocs = testing.AllocsPerRun(100, func() {
+			if _, err := Parse(input, time.UTC); err != nil {
+				t.Fatal(err)
+			}
+		})
+		assert.Equal(t, 0.0, allocs, input)
+
+		var d DefaultUTC
+		data := []byte(`"` + input + `"`)
+		allocs = testing.AllocsPerRun(100, func() {
+			if err := d.UnmarshalJSON(data); err != nil {
+				t.Fatal(err)
+			}
+		})
+		assert.Equal(t, 0.0, allocs, input)
+	}
+}
+
+var benchmarkInputs = []struct {
+	name  string
+	input string
+}{
+	{name: "UTC", input: "2007-11-30T10:10:10Z"},
+	{name: "Nano", input: "2007-11-30T10:10:10.123456789Z"},
+	{name: "Offset", input: "2007-11-30T10:10:10.123456789+05:30"},
+}
+
+func BenchmarkParseUTC(b *testing.B) {
+	for _, bm := range benchmarkInputs {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := ParseUTC(bm.input); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParseUTCGeneral(b *testing.B) {
+	// the same inputs through the general parser, which the fast path skips.
+	opts := newOptions(nil)
+	for _, bm := range benchmarkInputs {
+		input := []byte(bm.input)
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := newParser(input, opts).parse(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkTimeParse(b *testing.B) {
+	for _, bm := range benchmarkInputs {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := time.Parse(time.RFC3339Nano, bm.input); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	data := []byte(`"2007-11-30T10:10:10.123456789Z"`)
+	b.Run("DefaultUTC", func(b *testing.B) {
+		b.ReportAllocs()
+		var d DefaultUTC
+		for i := 0; i < b.N; i++ {
+			if err := d.UnmarshalJSON(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("time.Time", func(b *testing.B) {
+		b.ReportAllocs()
+		var t time.Time
+		for i := 0; i < b.N; i++ {
+			if err := json.Unmarshal(data, &t); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
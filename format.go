@@ -0,0 +1,206 @@
+package datetime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OffsetStyle is how Format writes a timestamp's offset from UTC.
+type OffsetStyle int
+
+const (
+	// OffsetExtended writes offsets like +01:00, and Z for UTC.  Offsets with seconds are written
+	// like +00:19:32.
+	OffsetExtended OffsetStyle = iota
+	// OffsetBasic writes offsets like +0100, and Z for UTC.  Offsets with seconds are written like
+	// +001932.
+	OffsetBasic
+	// OffsetHours writes offsets like +01, and Z for UTC.  Offsets that aren't whole hours can't be
+	// written this way.
+	OffsetHours
+	// OffsetZ converts the timestamp to UTC and writes Z.
+	OffsetZ
+	// OffsetNone leaves the offset out, so the timestamp is the wall clock time in its location.
+	OffsetNone
+)
+
+// FormatSpec describes how Format writes a timestamp.  The zero value writes the extended format
+// with trimmed fractional seconds and an extended offset, like time.RFC3339Nano.
+type FormatSpec struct {
+	// Basic writes the basic format, like 20071130T101010Z, instead of the extended format, like
+	// 2007-11-30T10:10:10Z.
+	Basic bool
+	// Precision is the smallest unit written.  Timestamps with less than PrecisionHour have no time
+	// or offset, and PrecisionWeek writes a week date like 2007-W48.  The zero value means
+	// PrecisionSubsecond.
+	Precision Precision
+	// FractionDigits is how many digits of fractional seconds are written for PrecisionSubsecond,
+	// truncating any more.  The zero value trims trailing zeros, leaving the fraction out entirely if
+	// it's zero.
+	FractionDigits int
+	// Offset is how the offset from UTC is written.
+	Offset OffsetStyle
+	// Comma writes a comma as the decimal sign instead of a dot.
+	Comma bool
+	// ExpandedYears writes the year with a sign and this many digits beyond the usual four, like the
+	// ExpandedYears option reads.  Without it, only years 0 through 9999 can be written.
+	ExpandedYears int
+}
+
+// Format writes t as an ISO 8601 timestamp following the spec.  The result can always be read back
+// by Parse, given the ExpandedYears option if the spec uses it, and the MaxOffset option if t's offset
+// is more than 18 hours from UTC.
+func Format(t time.Time, spec FormatSpec) (string, error) {
+	precision := spec.Precision
+	if precision == 0 {
+		precision = PrecisionSubsecond
+	}
+	if precision < PrecisionYear || precision > PrecisionSubsecond {
+		return "", fmt.Errorf("%s is not a valid precision", precision)
+	}
+	if spec.FractionDigits < 0 || spec.FractionDigits > 9 {
+		return "", fmt.Errorf("%d is not a valid number of fraction digits", spec.FractionDigits)
+	}
+	if spec.Offset == OffsetZ {
+		t = t.UTC()
+	}
+
+	var b strings.Builder
+	year := t.Year()
+	if precision == PrecisionWeek {
+		year, _ = t.ISOWeek()
+	}
+	if err := writeYear(&b, year, spec.ExpandedYears); err != nil {
+		return "", err
+	}
+
+	dateSep, timeSep := "-", ":"
+	if spec.Basic {
+		dateSep, timeSep = "", ""
+	}
+	switch precision {
+	case PrecisionYear:
+		return b.String(), nil
+	case PrecisionMonth:
+		// there's no basic format for a year and month.
+		fmt.Fprintf(&b, "-%02d", int(t.Month()))
+		return b.String(), nil
+	case PrecisionWeek:
+		_, week := t.ISOWeek()
+		fmt.Fprintf(&b, "%sW%02d", dateSep, week)
+		return b.String(), nil
+	}
+	fmt.Fprintf(&b, "%s%02d%s%02d", dateSep, int(t.Month()), dateSep, t.Day())
+	if precision == PrecisionDay {
+		return b.String(), nil
+	}
+
+	fmt.Fprintf(&b, "T%02d", t.Hour())
+	if precision >= PrecisionMinute {
+		fmt.Fprintf(&b, "%s%02d", timeSep, t.Minute())
+	}
+	if precision >= PrecisionSecond {
+		fmt.Fprintf(&b, "%s%02d", timeSep, t.Second())
+	}
+	if precision == PrecisionSubsecond {
+		writeFraction(&b, t.Nanosecond(), spec.FractionDigits, spec.Comma)
+	}
+
+	if err := writeOffset(&b, t, spec.Offset); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// writeYear writes the year with four digits, or with a sign and extra digits if extraDigits is more
+// than zero.
+func writeYear(b *strings.Builder, year, extraDigits int) error {
+	if extraDigits == 0 {
+		if year < 0 || year > 9999 {
+			return fmt.Errorf("year %d needs ExpandedYears to be written", year)
+		}
+		fmt.Fprintf(b, "%04d", year)
+		return nil
+	}
+
+	digits := strconv.Itoa(year)
+	sign := "+"
+	if year < 0 {
+		digits, sign = digits[1:], "-"
+	}
+	if len(digits) > 4+extraDigits {
+		return fmt.Errorf("year %d has more than %d digits", year, 4+extraDigits)
+	}
+	b.WriteString(sign)
+	b.WriteString(strings.Repeat("0", 4+extraDigits-len(digits)))
+	b.WriteString(digits)
+	return nil
+}
+
+// writeFraction writes the fraction of a second, with the given number of digits or trimmed if that's
+// zero.
+func writeFraction(b *strings.Builder, nsec, digits int, comma bool) {
+	frac := fmt.Sprintf("%09d", nsec)
+	if digits == 0 {
+		frac = strings.TrimRight(frac, "0")
+	} else {
+		frac = frac[:digits]
+	}
+	if frac == "" {
+		return
+	}
+	if comma {
+		b.WriteByte(',')
+	} else {
+		b.WriteByte('.')
+	}
+	b.WriteString(frac)
+}
+
+// formatNano formats t like time.RFC3339Nano, but keeps the seconds of an offset like +00:19:32,
+// which time.RFC3339Nano drops, so that it reads back as the same instant.
+func formatNano(t time.Time) string {
+	if _, offset := t.Zone(); offset%60 == 0 {
+		return t.Format(time.RFC3339Nano)
+	}
+	var b strings.Builder
+	b.WriteString(t.Format("2006-01-02T15:04:05.999999999"))
+	writeOffset(&b, t, OffsetExtended)
+	return b.String()
+}
+
+// writeOffset writes t's offset from UTC in the given style.
+func writeOffset(b *strings.Builder, t time.Time, style OffsetStyle) error {
+	if style == OffsetNone {
+		return nil
+	}
+	_, offset := t.Zone()
+	if offset == 0 {
+		b.WriteByte('Z')
+		return nil
+	}
+
+	sign := byte('+')
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	hours, minutes, seconds := offset/3600, offset%3600/60, offset%60
+	sep := ":"
+	switch style {
+	case OffsetHours:
+		if minutes != 0 || seconds != 0 {
+			return fmt.Errorf("offset %s isn't a whole number of hours", t.Format("-07:00:00"))
+		}
+		fmt.Fprintf(b, "%c%02d", sign, hours)
+		return nil
+	case OffsetBasic:
+		sep = ""
+	}
+	fmt.Fprintf(b, "%c%02d%s%02d", sign, hours, sep, minutes)
+	if seconds != 0 {
+		fmt.Fprintf(b, "%s%02d", sep, seconds)
+	}
+	return nil
+}
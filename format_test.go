@@ -0,0 +1,107 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	cet := time.FixedZone("CET", 60*60)
+	ist := time.FixedZone("IST", 5*60*60+30*60)
+	ams := time.FixedZone("AMT", 19*60+32)
+	ts := time.Date(2007, time.November, 30, 10, 10, 10, 123000000, cet)
+
+	tt := []struct {
+		t      time.Time
+		spec   FormatSpec
+		output string
+	}{
+		{t: ts, output: "2007-11-30T10:10:10.123+01:00"},
+		{t: ts, spec: FormatSpec{Offset: OffsetBasic}, output: "2007-11-30T10:10:10.123+0100"},
+		{t: ts, spec: FormatSpec{Offset: OffsetHours}, output: "2007-11-30T10:10:10.123+01"},
+		{t: ts, spec: FormatSpec{Offset: OffsetZ}, output: "2007-11-30T09:10:10.123Z"},
+		{t: ts, spec: FormatSpec{Offset: OffsetNone}, output: "2007-11-30T10:10:10.123"},
+		{t: ts, spec: FormatSpec{Basic: true, Precision: PrecisionSecond, Offset: OffsetZ}, output: "20071130T091010Z"},
+		{t: ts, spec: FormatSpec{Basic: true, Offset: OffsetBasic}, output: "20071130T101010.123+0100"},
+		{t: ts, spec: FormatSpec{FractionDigits: 6, Comma: true}, output: "2007-11-30T10:10:10,123000+01:00"},
+		{t: ts, spec: FormatSpec{FractionDigits: 1}, output: "2007-11-30T10:10:10.1+01:00"},
+		{t: ts.Truncate(time.Second), output: "2007-11-30T10:10:10+01:00"},
+		{t: ts, spec: FormatSpec{Precision: PrecisionMinute}, output: "2007-11-30T10:10+01:00"},
+		{t: ts, spec: FormatSpec{Precision: PrecisionHour, Basic: true}, output: "20071130T10+01:00"},
+		{t: ts, spec: FormatSpec{Precision: PrecisionDay}, output: "2007-11-30"},
+		{t: ts, spec: FormatSpec{Precision: PrecisionDay, Basic: true}, output: "20071130"},
+		{t: ts, spec: FormatSpec{Precision: PrecisionWeek}, output: "2007-W48"},
+		{t: ts, spec: FormatSpec{Precision: PrecisionWeek, Basic: true}, output: "2007W48"},
+		{t: ts, spec: FormatSpec{Precision: PrecisionMonth, Basic: true}, output: "2007-11"},
+		{t: ts, spec: FormatSpec{Precision: PrecisionYear}, output: "2007"},
+		{t: time.Date(2008, time.December, 29, 0, 0, 0, 0, time.UTC), spec: FormatSpec{Precision: PrecisionWeek}, output: "2009-W01"},
+		{t: time.Date(-44, time.March, 15, 0, 0, 0, 0, time.UTC), spec: FormatSpec{Precision: PrecisionDay, ExpandedYears: 2}, output: "-000044-03-15"},
+		{t: time.Date(12007, time.March, 15, 0, 0, 0, 0, time.UTC), spec: FormatSpec{Precision: PrecisionYear, ExpandedYears: 1}, output: "+12007"},
+		{t: time.Date(2007, time.November, 30, 10, 10, 10, 0, ist), spec: FormatSpec{Precision: PrecisionSecond}, output: "2007-11-30T10:10:10+05:30"},
+		{t: time.Date(1937, time.June, 30, 10, 10, 10, 0, ams), spec: FormatSpec{Precision: PrecisionSecond}, output: "1937-06-30T10:10:10+00:19:32"},
+		{t: time.Date(1937, time.June, 30, 10, 10, 10, 0, ams), spec: FormatSpec{Precision: PrecisionSecond, Offset: OffsetBasic}, output: "1937-06-30T10:10:10+001932"},
+	}
+
+	for _, tc := range tt {
+		out, err := Format(tc.t, tc.spec)
+		assert.Nil(t, err, tc.output)
+		assert.Equal(t, tc.output, out)
+	}
+
+	errs := []struct {
+		t    time.Time
+		spec FormatSpec
+		err  string
+	}{
+		{t: ts, spec: FormatSpec{Precision: Precision(9)}, err: "Precision(9) is not a valid precision"},
+		{t: ts, spec: FormatSpec{FractionDigits: 10}, err: "10 is not a valid number of fraction digits"},
+		{t: time.Date(12007, time.March, 15, 0, 0, 0, 0, time.UTC), err: "year 12007 needs ExpandedYears to be written"},
+		{t: time.Date(123456, time.March, 15, 0, 0, 0, 0, time.UTC), spec: FormatSpec{ExpandedYears: 1}, err: "year 123456 has more than 5 digits"},
+		{t: ts.In(ist), spec: FormatSpec{Offset: OffsetHours}, err: "offset +05:30:00 isn't a whole number of hours"},
+	}
+
+	for _, tc := range errs {
+		out, err := Format(tc.t, tc.spec)
+		assert.Equal(t, "", out, tc.err)
+		assert.EqualError(t, err, tc.err)
+	}
+}
+
+func TestFormatParses(t *testing.T) {
+	// everything Format writes can be read back by Parse, to the precision it was written with.
+	ts := time.Date(2007, time.November, 30, 10, 10, 10, 123456789, time.FixedZone("", -5*60*60))
+	for _, basic := range []bool{false, true} {
+		for precision := PrecisionYear; precision <= PrecisionSubsecond; precision++ {
+			for offset := OffsetExtended; offset <= OffsetNone; offset++ {
+				for _, comma := range []bool{false, true} {
+					spec := FormatSpec{Basic: basic, Precision: precision, Offset: offset, Comma: comma}
+					out, err := Format(ts, spec)
+					assert.Nil(t, err, out)
+
+					parsed, details, err := ParseDetails(out, ts.Location())
+					assert.Nil(t, err, out)
+					assert.Equal(t, precision, details.Precision, out)
+					again, err := Format(parsed, spec)
+					assert.Nil(t, err, out)
+					assert.Equal(t, out, again)
+				}
+			}
+		}
+	}
+}
+
+func TestFormatLargeOffset(t *testing.T) {
+	// offsets beyond the default limit need MaxOffset to be read back.
+	ts := time.Date(2007, time.November, 30, 10, 10, 10, 0, time.FixedZone("", 20*60*60))
+	out, err := Format(ts, FormatSpec{})
+	assert.Nil(t, err)
+	assert.Equal(t, "2007-11-30T10:10:10+20:00", out)
+
+	_, err = ParseUTC(out)
+	assert.EqualError(t, err, "+20:00 is more than 18:00 from UTC")
+	parsed, err := ParseUTC(out, MaxOffset(24*time.Hour))
+	assert.Nil(t, err)
+	assert.True(t, ts.Equal(parsed))
+}
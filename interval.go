@@ -0,0 +1,221 @@
+package datetime
+
+import (
+	"database/sql/driver"
+	"strings"
+	"time"
+)
+
+// Interval is an ISO 8601 time interval, the span of time from a start instant up to (but not
+// including) an end instant.
+type Interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// NewInterval returns the Interval from start to end.
+func NewInterval(start, end time.Time) Interval {
+	return Interval{start: start, end: end}
+}
+
+// ParseInterval takes a string with an ISO 8601 time interval in it, and a default location to use
+// for timestamps that don't include one, and returns an Interval.  These forms are supported:
+//
+//   - start/end, like 2007-03-01T13:00:00Z/2008-05-11T15:30:00Z
+//   - start/duration, like 2007-03-01T13:00:00Z/P1Y2M10DT2H30M
+//   - duration/end, like P1Y2M10DT2H30M/2008-05-11T15:30:00Z
+//
+// In the start/end form, the end may leave off leading components that are the same as the
+// start's, like 2007-12-14T13:30/15:30 or 2008-02-15/03-14.  An end without a timezone offset uses
+// the start's location.
+func ParseInterval(s string, defaultLocation *time.Location) (Interval, error) {
+	parts := splitSlashes(s, -1)
+	if len(parts) != 2 {
+		// point at the second slash, or the end if there's no slash at all.
+		offset, lit := len(s), ""
+		if len(parts) > 2 {
+			offset, lit = len(parts[0])+1+len(parts[1]), "/"
+		}
+		return Interval{}, inputError(s, offset, lit, []string{"/"}, SyntaxError,
+			"%s is not a start/end, start/duration, or duration/end interval", s)
+	}
+	first, second := parts[0], parts[1]
+	secondOffset := len(first) + 1
+
+	var start, end time.Time
+	switch {
+	case isDuration(first) && isDuration(second):
+		return Interval{}, inputError(s, 0, first, []string{"timestamp"}, SyntaxError, "%s has no start or end", s)
+	case isDuration(first):
+		d, err := ParseDuration(first)
+		if err != nil {
+			return Interval{}, withinInput(err, s, 0)
+		}
+		end, err = Parse(second, defaultLocation)
+		if err != nil {
+			return Interval{}, withinInput(err, s, secondOffset)
+		}
+		start = d.subtractFrom(end)
+	case isDuration(second):
+		var err error
+		start, err = Parse(first, defaultLocation)
+		if err != nil {
+			return Interval{}, withinInput(err, s, 0)
+		}
+		d, err := ParseDuration(second)
+		if err != nil {
+			return Interval{}, withinInput(err, s, secondOffset)
+		}
+		end = d.AddTo(start)
+	default:
+		var err error
+		start, err = Parse(first, defaultLocation)
+		if err != nil {
+			return Interval{}, withinInput(err, s, 0)
+		}
+		expanded := expandIntervalEnd(first, second)
+		end, err = Parse(expanded, start.Location())
+		if err != nil {
+			// the expanded end has the components copied from the start in front of the second part.
+			err = withinInput(err, s, secondOffset-(len(expanded)-len(second)))
+			if perr, ok := err.(*ParseError); ok && perr.Offset < secondOffset {
+				perr.Offset = secondOffset
+			}
+			return Interval{}, err
+		}
+	}
+
+	if end.Before(start) {
+		return Interval{}, inputError(s, secondOffset, second, nil, RangeError, "interval ends before it starts")
+	}
+	return Interval{start: start, end: end}, nil
+}
+
+// splitSlashes splits s into at most n parts around the slashes in it, like strings.SplitN, skipping
+// slashes inside brackets, like the one in a 2007-03-01T13:00:00-05:00[America/New_York] suffix.
+func splitSlashes(s string, n int) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s) && len(parts) != n-1; i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+			}
+		case '/':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// isDuration tells you whether an interval part is a duration rather than a timestamp.
+func isDuration(s string) bool {
+	return strings.HasPrefix(s, "P") || strings.HasPrefix(s, "-P")
+}
+
+// expandIntervalEnd fills in any leading components left off of an interval's end from its start.
+// A date that doesn't begin with a four digit year is missing leading components, and times always
+// start with the hour, so an end like 15:30 is a time on the start's date.
+func expandIntervalEnd(start, end string) string {
+	startDate := strings.SplitN(start, "T", 2)[0]
+	// a suffix like [America/Toronto] may have a T or colon in it too.
+	stamp := end
+	if i := strings.IndexByte(end, '['); i >= 0 {
+		stamp = end[:i]
+	}
+	endDate, endTime := end, ""
+	if i := strings.Index(stamp, "T"); i >= 0 {
+		endDate, endTime = end[:i], end[i:]
+	} else if strings.Contains(stamp, ":") {
+		endDate, endTime = "", "T"+end
+	}
+
+	leadingDigits := len(endDate) - len(strings.TrimLeft(endDate, "0123456789"))
+	if leadingDigits < 4 && len(endDate) < len(startDate) {
+		endDate = startDate[:len(startDate)-len(endDate)] + endDate
+	}
+	return endDate + endTime
+}
+
+// Start returns the beginning of the interval.
+func (i Interval) Start() time.Time { return i.start }
+
+// End returns the end of the interval, which is not contained in it.
+func (i Interval) End() time.Time { return i.end }
+
+// Duration returns the exact length of the interval.
+func (i Interval) Duration() time.Duration { return i.end.Sub(i.start) }
+
+// Contains returns whether t is at or after the start of the interval and before its end.
+func (i Interval) Contains(t time.Time) bool {
+	return !t.Before(i.start) && t.Before(i.end)
+}
+
+// Overlaps returns whether any instant is contained in both intervals.
+func (i Interval) Overlaps(other Interval) bool {
+	return i.start.Before(other.end) && other.start.Before(i.end)
+}
+
+// String returns the Interval's representation as RFC3339Nano start and end timestamps separated by
+// a slash.
+func (i Interval) String() string {
+	return formatNano(i.start) + "/" + formatNano(i.end)
+}
+
+// MarshalText implements the encoding TextMarshaler interface.
+func (i Interval) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding TextUnmarshaler interface.  Like DefaultUTC, it uses time.UTC
+// as the location for timestamps that don't specify one.
+func (i *Interval) UnmarshalText(data []byte) error {
+	parsed, err := ParseInterval(string(data), time.UTC)
+	*i = parsed
+	return err
+}
+
+// MarshalJSON implements the JSON Marshaler interface, writing the interval as a JSON string.
+func (i Interval) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + i.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the JSON Unmarshaler interface, allowing datetime.Interval struct fields
+// to be read from JSON string fields.
+func (i *Interval) UnmarshalJSON(data []byte) error {
+	// Ignore null, like in the main JSON package.
+	if string(data) == "null" {
+		*i = Interval{}
+		return nil
+	}
+	trimmed, err := trimQuotes(data)
+	if err != nil {
+		*i = Interval{}
+		return err
+	}
+	// report the error's position in the quoted JSON, not the string inside it.
+	return withinInput(i.UnmarshalText(trimmed), string(data), 1)
+}
+
+// Scan implements the sql Scanner interface, allowing datetime.Interval fields to be read from
+// database columns.
+func (i *Interval) Scan(value interface{}) error {
+	b, err := scanBytes(value)
+	if err != nil {
+		*i = Interval{}
+		return err
+	}
+	return i.UnmarshalText(b)
+}
+
+// Value implements the sql Valuer interface, allowing datetime.Interval fields to be saved to
+// database columns.
+func (i Interval) Value() (driver.Value, error) {
+	return i.String(), nil
+}
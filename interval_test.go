@@ -0,0 +1,213 @@
+package datetime
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInterval(t *testing.T) {
+	est := time.FixedZone("-05:00", -5*60*60)
+	ny, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+	tt := []struct {
+		input string
+		start time.Time
+		end   time.Time
+	}{
+		{
+			input: "2007-03-01T13:00:00Z/2008-05-11T15:30:00Z",
+			start: time.Date(2007, time.March, 1, 13, 0, 0, 0, time.UTC),
+			end:   time.Date(2008, time.May, 11, 15, 30, 0, 0, time.UTC),
+		},
+		{
+			input: "2007-03-01T13:00:00Z/P1Y2M10DT2H30M",
+			start: time.Date(2007, time.March, 1, 13, 0, 0, 0, time.UTC),
+			end:   time.Date(2008, time.May, 11, 15, 30, 0, 0, time.UTC),
+		},
+		{
+			input: "P1Y2M10DT2H30M/2008-05-11T15:30:00Z",
+			start: time.Date(2007, time.March, 1, 13, 0, 0, 0, time.UTC),
+			end:   time.Date(2008, time.May, 11, 15, 30, 0, 0, time.UTC),
+		},
+		{
+			input: "P1Y/2008-05-11",
+			start: time.Date(2007, time.May, 11, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2008, time.May, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "2007-12-14T13:30/15:30",
+			start: time.Date(2007, time.December, 14, 13, 30, 0, 0, time.UTC),
+			end:   time.Date(2007, time.December, 14, 15, 30, 0, 0, time.UTC),
+		},
+		{
+			input: "2007-12-14T13:30-05:00/15:30",
+			start: time.Date(2007, time.December, 14, 13, 30, 0, 0, est),
+			end:   time.Date(2007, time.December, 14, 15, 30, 0, 0, est),
+		},
+		{
+			input: "2008-02-15/03-14",
+			start: time.Date(2008, time.February, 15, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2008, time.March, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "2007-11-13T09:00/15T17:00",
+			start: time.Date(2007, time.November, 13, 9, 0, 0, 0, time.UTC),
+			end:   time.Date(2007, time.November, 15, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "20080215/16",
+			start: time.Date(2008, time.February, 15, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2008, time.February, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "2007-03-01/2008",
+			start: time.Date(2007, time.March, 1, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2008, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// the slash in a suffix doesn't split the interval.
+			input: "2007-03-01T13:00:00-05:00[America/New_York]/P1D",
+			start: time.Date(2007, time.March, 1, 13, 0, 0, 0, ny),
+			end:   time.Date(2007, time.March, 2, 13, 0, 0, 0, ny),
+		},
+		{
+			input: "P1D/2007-03-02T13:00:00-05:00[America/New_York]",
+			start: time.Date(2007, time.March, 1, 13, 0, 0, 0, ny),
+			end:   time.Date(2007, time.March, 2, 13, 0, 0, 0, ny),
+		},
+		{
+			input: "2007-03-01T13:00:00-05:00[America/New_York]/2007-03-02T13:00:00Z[America/New_York]",
+			start: time.Date(2007, time.March, 1, 13, 0, 0, 0, ny),
+			end:   time.Date(2007, time.March, 2, 8, 0, 0, 0, ny),
+		},
+		{
+			input: "2007-03-01T13:00:00-05:00[America/New_York]/15:30[America/New_York]",
+			start: time.Date(2007, time.March, 1, 13, 0, 0, 0, ny),
+			end:   time.Date(2007, time.March, 1, 15, 30, 0, 0, ny),
+		},
+	}
+
+	for _, tc := range tt {
+		i, err := ParseInterval(tc.input, time.UTC)
+		assert.Nil(t, err, tc.input)
+		assert.Equal(t, tc.start, i.Start(), tc.input)
+		assert.Equal(t, tc.end, i.End(), tc.input)
+	}
+}
+
+func TestParseIntervalErrors(t *testing.T) {
+	tt := []struct {
+		input string
+		err   string
+	}{
+		{
+			input: "2007-03-01",
+			err:   "2007-03-01 is not a start/end, start/duration, or duration/end interval",
+		},
+		{
+			input: "2007/2008/2009",
+			err:   "2007/2008/2009 is not a start/end, start/duration, or duration/end interval",
+		},
+		{
+			input: "P1D/P2D",
+			err:   "P1D/P2D has no start or end",
+		},
+		{
+			input: "2008-05-11/2007-03-01",
+			err:   "interval ends before it starts",
+		},
+		{
+			input: "2007-03-01/P1Q",
+			err:   "found Q, expected duration designator",
+		},
+		{
+			input: "P1Q/2007-03-01",
+			err:   "found Q, expected duration designator",
+		},
+		{
+			input: "2007-03-01/2007-13",
+			err:   "13 is not a valid month",
+		},
+		{
+			input: "2007-13/P1D",
+			err:   "13 is not a valid month",
+		},
+		{
+			input: "P1D/2007-13",
+			err:   "13 is not a valid month",
+		},
+	}
+
+	for _, tc := range tt {
+		i, err := ParseInterval(tc.input, time.UTC)
+		assert.Equal(t, Interval{}, i, tc.input)
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+}
+
+func TestIntervalContainsOverlaps(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2007, time.March, d, 0, 0, 0, 0, time.UTC) }
+	i := NewInterval(day(1), day(10))
+
+	assert.True(t, i.Contains(day(1)))
+	assert.True(t, i.Contains(day(5)))
+	assert.False(t, i.Contains(day(10)))
+	assert.False(t, i.Contains(day(11)))
+
+	assert.True(t, i.Overlaps(NewInterval(day(9), day(12))))
+	assert.True(t, i.Overlaps(NewInterval(day(2), day(3))))
+	assert.False(t, i.Overlaps(NewInterval(day(10), day(12))))
+	assert.Equal(t, 9*24*time.Hour, i.Duration())
+}
+
+func TestIntervalJSON(t *testing.T) {
+	type wrapper struct {
+		I Interval `json:"i"`
+	}
+
+	i, err := ParseInterval("2007-03-01T13:00:00Z/P1D", time.UTC)
+	assert.Nil(t, err)
+
+	out, err := json.Marshal(wrapper{I: i})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"i":"2007-03-01T13:00:00Z/2007-03-02T13:00:00Z"}`, string(out))
+
+	var w wrapper
+	assert.Nil(t, json.Unmarshal(out, &w))
+	assert.Equal(t, i, w.I)
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"i":null}`), &w))
+	assert.Equal(t, Interval{}, w.I)
+
+	// offset seconds are kept, so the interval reads back the same.
+	i, err = ParseInterval("1937-06-30T10:00:00+00:19:32/PT1H", time.UTC)
+	assert.Nil(t, err)
+	assert.Equal(t, "1937-06-30T10:00:00+00:19:32/1937-06-30T11:00:00+00:19:32", i.String())
+	out, err = json.Marshal(wrapper{I: i})
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(out, &w))
+	assert.True(t, i.Start().Equal(w.I.Start()))
+	assert.True(t, i.End().Equal(w.I.End()))
+
+	err = json.Unmarshal([]byte(`{"i":1}`), &w)
+	assert.EqualError(t, err, "1 does not begin and end with double quotes")
+}
+
+func TestIntervalScanValue(t *testing.T) {
+	var i Interval
+	assert.Nil(t, i.Scan([]byte("2007-03-01/2007-03-02")))
+	assert.Equal(t, time.Date(2007, time.March, 2, 0, 0, 0, 0, time.UTC), i.End())
+
+	assert.Nil(t, i.Scan("2007-03-01T13:00:00Z/PT1H"))
+	assert.Equal(t, time.Date(2007, time.March, 1, 14, 0, 0, 0, time.UTC), i.End())
+
+	assert.EqualError(t, i.Scan(1), "can only scan string and []byte, not int")
+	assert.Equal(t, Interval{}, i)
+
+	val, err := NewInterval(time.Date(2007, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2007, time.March, 2, 0, 0, 0, 0, time.UTC)).Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "2007-03-01T00:00:00Z/2007-03-02T00:00:00Z", val)
+}
@@ -0,0 +1,247 @@
+package datetime
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LocalDateTime is a date and time on a wall clock, without a location, like an input such as
+// 2007-11-30T10:10:10 that didn't say where it was.  Unlike DefaultUTC, it keeps the fact that no
+// location was given, so it can be resolved in the right one later with In.  The zero value has no
+// date or time.
+type LocalDateTime struct {
+	Year       int
+	Month      time.Month
+	Day        int
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// ParseLocalDateTime takes a string with an ISO 8601 timestamp without a Z or offset in it, like
+// 2007-11-30T10:10:10, and returns a LocalDateTime.  All the formats Parse accepts are allowed, and
+// reduced precision timestamps are at the start of the period they cover.  24:00 and leap seconds
+// are normalized to the next day and minute.
+func ParseLocalDateTime(s string, opts ...Option) (LocalDateTime, error) {
+	return parseLocalDateTimeBytes([]byte(s), opts)
+}
+
+// LocalDateTimeOf returns the date and time on t's wall clock, without its location.
+func LocalDateTimeOf(t time.Time) LocalDateTime {
+	year, month, day := t.Date()
+	return LocalDateTime{
+		Year:       year,
+		Month:      month,
+		Day:        day,
+		Hour:       t.Hour(),
+		Minute:     t.Minute(),
+		Second:     t.Second(),
+		Nanosecond: t.Nanosecond(),
+	}
+}
+
+// Date returns the local date and time's date.
+func (l LocalDateTime) Date() Date {
+	return Date{Year: l.Year, Month: l.Month, Day: l.Day}
+}
+
+// TimeOfDay returns the local date and time's time of day, without a location.
+func (l LocalDateTime) TimeOfDay() TimeOfDay {
+	return TimeOfDay{Hour: l.Hour, Minute: l.Minute, Second: l.Second, Nanosecond: l.Nanosecond}
+}
+
+// Resolution says how a LocalDateTime was resolved to a time.Time in a location.
+type Resolution int
+
+const (
+	// ResolutionUnique means the wall clock showed the local date and time exactly once.
+	ResolutionUnique Resolution = iota + 1
+	// ResolutionGap means the wall clock skipped over the local date and time, like at the start of
+	// daylight saving time.
+	ResolutionGap
+	// ResolutionOverlap means the wall clock showed the local date and time twice, like at the end of
+	// daylight saving time.
+	ResolutionOverlap
+)
+
+// String returns the resolution's name.
+func (r Resolution) String() string {
+	switch r {
+	case ResolutionUnique:
+		return "unique"
+	case ResolutionGap:
+		return "gap"
+	case ResolutionOverlap:
+		return "overlap"
+	default:
+		return fmt.Sprintf("Resolution(%d)", int(r))
+	}
+}
+
+// In returns the time.Time when the wall clock in loc shows the local date and time, and whether it
+// did so once, never, or twice.  In a gap, the time is moved later by the length of the gap, so
+// 02:30 on a day when clocks go from 02:00 to 03:00 is 03:30.  In an overlap, the earlier of the two
+// times is returned.
+func (l LocalDateTime) In(loc *time.Location) (time.Time, Resolution) {
+	// wall is the local date and time as if it were in UTC, so the offsets in loc can be subtracted.
+	wall := time.Date(l.Year, l.Month, l.Day, l.Hour, l.Minute, l.Second, l.Nanosecond, time.UTC)
+	want := LocalDateTimeOf(wall)
+
+	// offsets change at most once within a day of any time in practice, so the offsets from a day
+	// either side are the only ones that could apply.
+	before := offsetAt(wall.Add(-24*time.Hour), loc)
+	after := offsetAt(wall.Add(24*time.Hour), loc)
+
+	var found []time.Time
+	for _, offset := range []int{before, after} {
+		t := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		if LocalDateTimeOf(t) == want && (len(found) == 0 || !found[0].Equal(t)) {
+			found = append(found, t)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		// using the offset from before the gap gives a time after it.
+		return wall.Add(-time.Duration(before) * time.Second).In(loc), ResolutionGap
+	case 1:
+		return found[0], ResolutionUnique
+	default:
+		if found[1].Before(found[0]) {
+			return found[1], ResolutionOverlap
+		}
+		return found[0], ResolutionOverlap
+	}
+}
+
+// offsetAt returns loc's offset from UTC at t, in seconds.
+func offsetAt(t time.Time, loc *time.Location) int {
+	_, offset := t.In(loc).Zone()
+	return offset
+}
+
+// Before tells you whether l is earlier on the wall clock than other.
+func (l LocalDateTime) Before(other LocalDateTime) bool {
+	return l.asUTC().Before(other.asUTC())
+}
+
+// After tells you whether l is later on the wall clock than other.
+func (l LocalDateTime) After(other LocalDateTime) bool {
+	return other.Before(l)
+}
+
+// asUTC returns the local date and time as if it were in UTC, for comparisons.
+func (l LocalDateTime) asUTC() time.Time {
+	return time.Date(l.Year, l.Month, l.Day, l.Hour, l.Minute, l.Second, l.Nanosecond, time.UTC)
+}
+
+// IsZero tells you whether l is the zero value.
+func (l LocalDateTime) IsZero() bool {
+	return l == LocalDateTime{}
+}
+
+// String returns the local date and time in the ISO 8601 extended format without an offset, like
+// 2007-11-30T10:10:10 or 2007-11-30T10:10:10.5.
+func (l LocalDateTime) String() string {
+	var b strings.Builder
+	b.WriteString(l.Date().String())
+	fmt.Fprintf(&b, "T%02d:%02d:%02d", l.Hour, l.Minute, l.Second)
+	if l.Nanosecond != 0 {
+		b.WriteByte('.')
+		b.WriteString(strings.TrimRight(fmt.Sprintf("%09d", l.Nanosecond), "0"))
+	}
+	return b.String()
+}
+
+// MarshalText implements the encoding TextMarshaler interface.  The zero value is written as an
+// empty string.
+func (l LocalDateTime) MarshalText() ([]byte, error) {
+	if l.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements the encoding TextUnmarshaler interface.  An empty string is read as the
+// zero value.
+func (l *LocalDateTime) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*l = LocalDateTime{}
+		return nil
+	}
+	parsed, err := parseLocalDateTimeBytes(data, nil)
+	*l = parsed
+	return err
+}
+
+// MarshalJSON implements the JSON Marshaler interface, writing the local date and time as a JSON
+// string without an offset.  The zero value is written as null, to match UnmarshalJSON.
+func (l LocalDateTime) MarshalJSON() ([]byte, error) {
+	if l.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + l.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the JSON Unmarshaler interface, allowing datetime.LocalDateTime struct
+// fields to be read from JSON string fields.
+func (l *LocalDateTime) UnmarshalJSON(data []byte) error {
+	// Ignore null, like in the main JSON package.
+	if string(data) == "null" {
+		*l = LocalDateTime{}
+		return nil
+	}
+	trimmed, err := trimQuotes(data)
+	if err != nil {
+		*l = LocalDateTime{}
+		return err
+	}
+	return l.UnmarshalText(trimmed)
+}
+
+// Scan implements the sql Scanner interface, allowing datetime.LocalDateTime fields to be read from
+// database columns like TIMESTAMP WITHOUT TIME ZONE.  A time.Time's wall clock is used, ignoring its
+// location, and NULL is read as the zero value.
+func (l *LocalDateTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*l = LocalDateTime{}
+		return nil
+	case time.Time:
+		*l = LocalDateTimeOf(v)
+		return nil
+	}
+	b, err := scanBytes(value)
+	if err != nil {
+		*l = LocalDateTime{}
+		return err
+	}
+	return l.UnmarshalText(b)
+}
+
+// Value implements the sql Valuer interface, allowing datetime.LocalDateTime fields to be saved to
+// database columns.  The zero value is saved as NULL.
+func (l LocalDateTime) Value() (driver.Value, error) {
+	if l.IsZero() {
+		return nil, nil
+	}
+	return l.String(), nil
+}
+
+func parseLocalDateTimeBytes(b []byte, opts []Option) (LocalDateTime, error) {
+	o := newOptions(opts)
+	// the location is only used to hold the wall clock until it's taken out of the time.Time.
+	o.location = time.UTC
+	p := newParser(b, o)
+	t, err := p.parse()
+	if err != nil {
+		return LocalDateTime{}, err
+	}
+	if p.details.Zone != ZoneDefault {
+		return LocalDateTime{}, p.errorAt(SyntaxError, p.fields.zone, []string{"EOF"}, "found %s, expected EOF", p.fields.zone.lit)
+	}
+	return LocalDateTimeOf(t), nil
+}
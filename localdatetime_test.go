@@ -0,0 +1,164 @@
+package datetime
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+	_ "time/tzdata" // so the DST tests don't depend on the system's zoneinfo
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocalDateTime(t *testing.T) {
+	tt := []struct {
+		input  string
+		output LocalDateTime
+	}{
+		{input: "2007-11-30T10:10:10", output: LocalDateTime{2007, time.November, 30, 10, 10, 10, 0}},
+		{input: "20071130T101010.5", output: LocalDateTime{2007, time.November, 30, 10, 10, 10, 500000000}},
+		{input: "2007-11-30", output: LocalDateTime{2007, time.November, 30, 0, 0, 0, 0}},
+		{input: "2007-11-30T24:00", output: LocalDateTime{2007, time.December, 1, 0, 0, 0, 0}},
+	}
+
+	for _, tc := range tt {
+		l, err := ParseLocalDateTime(tc.input)
+		assert.Equal(t, tc.output, l, tc.input)
+		assert.Nil(t, err, tc.input)
+	}
+
+	errs := []struct {
+		input string
+		err   string
+	}{
+		{input: "2007-11-30T10:10:10Z", err: "found Z, expected EOF"},
+		{input: "2007-11-30T10:10:10-05:00", err: "found -05:00, expected EOF"},
+		{input: "2007-11-30T25:10:10", err: "25 is not a valid hour"},
+	}
+
+	for _, tc := range errs {
+		l, err := ParseLocalDateTime(tc.input)
+		assert.Equal(t, LocalDateTime{}, l, tc.input)
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+
+	// the error points at the timezone.
+	_, err := ParseLocalDateTime("2007-11-30T10:10:10+01:00")
+	perr, ok := err.(*ParseError)
+	assert.True(t, ok)
+	assert.Equal(t, 19, perr.Offset)
+}
+
+func TestLocalDateTimeIn(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+	edt := time.FixedZone("EDT", -4*60*60)
+	est := time.FixedZone("EST", -5*60*60)
+
+	tt := []struct {
+		input      LocalDateTime
+		output     time.Time
+		resolution Resolution
+	}{
+		{
+			input:      LocalDateTime{2007, time.November, 30, 10, 10, 10, 0},
+			output:     time.Date(2007, time.November, 30, 10, 10, 10, 0, est),
+			resolution: ResolutionUnique,
+		},
+		{
+			// clocks went from 02:00 to 03:00.
+			input:      LocalDateTime{2007, time.March, 11, 2, 30, 0, 0},
+			output:     time.Date(2007, time.March, 11, 3, 30, 0, 0, edt),
+			resolution: ResolutionGap,
+		},
+		{
+			// clocks went from 02:00 back to 01:00.
+			input:      LocalDateTime{2007, time.November, 4, 1, 30, 0, 0},
+			output:     time.Date(2007, time.November, 4, 1, 30, 0, 0, edt),
+			resolution: ResolutionOverlap,
+		},
+		{
+			input:      LocalDateTime{2007, time.November, 4, 2, 30, 0, 0},
+			output:     time.Date(2007, time.November, 4, 2, 30, 0, 0, est),
+			resolution: ResolutionUnique,
+		},
+	}
+
+	for _, tc := range tt {
+		out, resolution := tc.input.In(ny)
+		assert.True(t, tc.output.Equal(out), "%s: %s", tc.input, out)
+		assert.Equal(t, ny, out.Location(), tc.input.String())
+		assert.Equal(t, tc.resolution, resolution, tc.input.String())
+	}
+
+	out, resolution := LocalDateTime{2007, time.November, 30, 10, 10, 10, 0}.In(time.UTC)
+	assert.Equal(t, time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC), out)
+	assert.Equal(t, ResolutionUnique, resolution)
+}
+
+func TestLocalDateTime(t *testing.T) {
+	l := LocalDateTime{2007, time.November, 30, 10, 10, 10, 500000000}
+	est := time.FixedZone("EST", -5*60*60)
+
+	assert.Equal(t, l, LocalDateTimeOf(time.Date(2007, time.November, 30, 10, 10, 10, 500000000, est)))
+	assert.Equal(t, Date{2007, time.November, 30}, l.Date())
+	assert.Equal(t, TimeOfDay{Hour: 10, Minute: 10, Second: 10, Nanosecond: 500000000}, l.TimeOfDay())
+	assert.True(t, l.Before(LocalDateTime{2007, time.November, 30, 10, 10, 11, 0}))
+	assert.True(t, l.After(LocalDateTime{2007, time.November, 29, 23, 0, 0, 0}))
+	assert.Equal(t, "2007-11-30T10:10:10.5", l.String())
+	assert.True(t, LocalDateTime{}.IsZero())
+
+	assert.Equal(t, "unique", ResolutionUnique.String())
+	assert.Equal(t, "gap", ResolutionGap.String())
+	assert.Equal(t, "overlap", ResolutionOverlap.String())
+	assert.Equal(t, "Resolution(0)", Resolution(0).String())
+}
+
+func TestLocalDateTimeJSON(t *testing.T) {
+	var out struct {
+		L LocalDateTime `json:"l"`
+	}
+	err := json.Unmarshal([]byte(`{"l":"20071130T1010"}`), &out)
+	assert.Nil(t, err)
+
+	b, err := json.Marshal(out)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"l":"2007-11-30T10:10:00"}`, string(b))
+
+	err = json.Unmarshal([]byte(`{"l":null}`), &out)
+	assert.Nil(t, err)
+	b, err = json.Marshal(out)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"l":null}`, string(b))
+
+	err = json.Unmarshal([]byte(`{"l":"2007-11-30T10:10Z"}`), &out)
+	assert.EqualError(t, err, "found Z, expected EOF")
+}
+
+func TestLocalDateTimeScanValue(t *testing.T) {
+	tt := []struct {
+		input  interface{}
+		output LocalDateTime
+		err    string
+	}{
+		{input: "2007-11-30T10:10:10", output: LocalDateTime{2007, time.November, 30, 10, 10, 10, 0}},
+		{input: []byte("2007-11-30 10:10:10"), err: "found  , expected T or EOF"},
+		{input: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC), output: LocalDateTime{2007, time.November, 30, 10, 10, 10, 0}},
+		{input: nil},
+	}
+
+	for _, tc := range tt {
+		var l LocalDateTime
+		err := l.Scan(tc.input)
+		assertErrorMessage(t, tc.err, err)
+		assert.Equal(t, tc.output, l)
+	}
+
+	v, err := LocalDateTime{2007, time.November, 30, 10, 10, 10, 0}.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, driver.Value("2007-11-30T10:10:10"), v)
+
+	v, err = LocalDateTime{}.Value()
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+}
@@ -0,0 +1,125 @@
+package datetime
+
+import "time"
+
+// Option changes how timestamps are parsed.  Options are passed to NewParser, or to Parse, ParseUTC,
+// and ParseLocal.
+type Option func(*options)
+
+type options struct {
+	location          *time.Location
+	lenient           bool
+	noLeapSeconds     bool
+	truncateFractions bool
+	expandedYears     int
+	separators        string
+	requireOffset     bool
+	precisions        []Precision
+	profile           profile
+	maxOffset         time.Duration
+}
+
+// profile is a stricter grammar that a Parser can follow instead of this package's own ISO 8601
+// subset.
+type profile int
+
+const (
+	profileISO8601 profile = iota
+	profileRFC3339
+	profileXSDDateTime
+	profileXSDDate
+	profileXSDGYearMonth
+	profileXMLSchema
+	profileW3CDTF
+)
+
+func newOptions(opts []Option) options {
+	o := options{location: time.UTC, separators: "T", maxOffset: defaultMaxOffset}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// separator tells you whether the given literal is allowed between the date and time.
+func (o options) separator(lit string) bool {
+	for _, r := range o.separators {
+		if string(r) == lit {
+			return true
+		}
+	}
+	return false
+}
+
+// precision tells you whether timestamps with the given precision are allowed.
+func (o options) precision(p Precision) bool {
+	if o.precisions == nil {
+		return true
+	}
+	for _, allowed := range o.precisions {
+		if allowed == p {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultLocation sets the location to use for timestamps that don't include one.  Without this
+// option, a Parser uses time.UTC.
+func DefaultLocation(loc *time.Location) Option {
+	return func(o *options) { o.location = loc }
+}
+
+// Lenient turns off leap year checking, allowing February 29th in every year as older versions of
+// this package did.  Like time.Date, it normalizes dates like 2007-02-29 to March 1st.
+func Lenient() Option {
+	return func(o *options) { o.lenient = true }
+}
+
+// RejectLeapSeconds makes timestamps with a :60 leap second an error, instead of mapping them to the
+// next second.
+func RejectLeapSeconds() Option {
+	return func(o *options) { o.noLeapSeconds = true }
+}
+
+// TruncateFractions makes fractions of a second, minute, or hour that are more precise than a
+// nanosecond round down instead of to the nearest nanosecond.
+func TruncateFractions() Option {
+	return func(o *options) { o.truncateFractions = true }
+}
+
+// ExpandedYears allows ISO 8601 expanded years, which have a + or - sign and the given number of
+// digits beyond the usual four, like +012007-11-30 or -000044-03-15 with two extra digits.  Years
+// are numbered astronomically, so year 0 is 1 BC and year -44 is 45 BC.
+func ExpandedYears(extraDigits int) Option {
+	return func(o *options) { o.expandedYears = extraDigits }
+}
+
+// Separators sets which characters may separate the date from the time.  ISO 8601 only allows 'T',
+// which is the default, but RFC 3339 also allows 't' and ' '.  Those are the only ones supported.
+func Separators(seps ...rune) Option {
+	return func(o *options) { o.separators = string(seps) }
+}
+
+// RequireOffset makes timestamps without a Z or timezone offset an error, instead of using the
+// default location.
+func RequireOffset() Option {
+	return func(o *options) { o.requireOffset = true }
+}
+
+// defaultMaxOffset is the furthest from UTC that a timezone offset can be without the MaxOffset
+// option, which is what most RFC 3339 implementations allow.
+const defaultMaxOffset = 18 * time.Hour
+
+// MaxOffset sets the furthest from UTC that a timezone offset can be.  Without this option, offsets up
+// to ±18:00 are allowed.
+func MaxOffset(max time.Duration) Option {
+	return func(o *options) { o.maxOffset = max }
+}
+
+// Precisions limits which precisions are allowed, so that for example a Parser given
+// Precisions(PrecisionSecond, PrecisionSubsecond) rejects timestamps without seconds.  Without this
+// option, all precisions are allowed.
+func Precisions(precisions ...Precision) Option {
+	return func(o *options) { o.precisions = precisions }
+}
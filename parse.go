@@ -1,21 +1,179 @@
 package datetime
 
 import (
-	"bytes"
+	"fmt"
 	"time"
 )
 
+// Parser parses ISO 8601 timestamps with a fixed set of options.  It's safe for concurrent use by
+// multiple goroutines.
+type Parser struct {
+	opts options
+}
+
+// NewParser returns a Parser configured with the given options.  Without any, it behaves like
+// ParseUTC.
+func NewParser(opts ...Option) *Parser {
+	return &Parser{opts: newOptions(opts)}
+}
+
+var (
+	utcParser   = NewParser()
+	localParser = NewParser(DefaultLocation(time.Local))
+)
+
+// Parse takes a string with a ISO 8601 timestamp in it and returns a time.Time.  Impossible dates
+// like 2007-02-29 are rejected unless the Parser has the Lenient option.
+//
+// An hour of 24:00 is the end of the day, and is returned as midnight of the next day.  A :60 leap
+// second is returned as the start of the next minute, unless the Parser has the RejectLeapSeconds
+// option.
+func (p *Parser) Parse(s string) (time.Time, error) {
+	// short inputs converted just for the fast path don't escape, so they can stay on the stack.
+	if t, _, ok := p.parseFast([]byte(s)); ok {
+		return t, nil
+	}
+	return newParser([]byte(s), p.opts).parse()
+}
+
+// ParseDetails is like Parse, but also returns the Details that were lost in the time.Time.
+func (p *Parser) ParseDetails(s string) (time.Time, Details, error) {
+	if t, details, ok := p.parseFast([]byte(s)); ok {
+		return t, details, nil
+	}
+	pp := newParser([]byte(s), p.opts)
+	t, err := pp.parse()
+	if err != nil {
+		return t, Details{}, err
+	}
+	return t, pp.details, nil
+}
+
+// ParseBytes is like Parse, but takes the timestamp as bytes, so that ones read from a network
+// buffer or file don't have to be converted to a string first.  The Parser doesn't keep b.
+func (p *Parser) ParseBytes(b []byte) (time.Time, error) {
+	if t, _, ok := p.parseFast(b); ok {
+		return t, nil
+	}
+	return newParser(b, p.opts).parse()
+}
+
+// withLocation returns the options with a default location added after them, so that it wins over
+// any DefaultLocation option in them.
+func withLocation(opts []Option, loc *time.Location) []Option {
+	return append(opts[:len(opts):len(opts)], DefaultLocation(loc))
+}
+
 // Parse takes a string with a ISO 8601 timestamp in it, and a default location to use for
-// timestamps that don't include one, and returns a time.Time.
-func Parse(s string, defaultLocation *time.Location) (time.Time, error) {
-	p := newParser(bytes.NewBuffer([]byte(s)))
-	return p.parse(defaultLocation)
+// timestamps that don't include one, and returns a time.Time.  Impossible dates like 2007-02-29 are
+// rejected unless the Lenient option is given.
+//
+// An hour of 24:00 is the end of the day, and is returned as midnight of the next day.  A :60 leap
+// second is returned as the start of the next minute, unless the RejectLeapSeconds option is given.
+func Parse(s string, defaultLocation *time.Location, opts ...Option) (time.Time, error) {
+	if len(opts) == 0 {
+		return parserFor(defaultLocation).Parse(s)
+	}
+	return NewParser(withLocation(opts, defaultLocation)...).Parse(s)
+}
+
+// ParseBytes is like Parse, but takes the timestamp as bytes.
+func ParseBytes(b []byte, defaultLocation *time.Location, opts ...Option) (time.Time, error) {
+	if len(opts) == 0 {
+		return parserFor(defaultLocation).ParseBytes(b)
+	}
+	return NewParser(withLocation(opts, defaultLocation)...).ParseBytes(b)
+}
+
+// ParseWithLeapSecond is like Parse, but also returns whether the timestamp had a :60 leap second,
+// since time.Time can't represent one.
+func ParseWithLeapSecond(s string, defaultLocation *time.Location, opts ...Option) (time.Time, bool, error) {
+	t, details, err := ParseDetails(s, defaultLocation, opts...)
+	return t, details.LeapSecond, err
+}
+
+// ParseWithPrecision is like Parse, but also returns the precision of the timestamp, so that for
+// example 2007-11 can be told apart from 2007-11-01T00:00.  Use ParseDetails to also get the number
+// of fraction digits and how the location was given.
+func ParseWithPrecision(s string, defaultLocation *time.Location, opts ...Option) (time.Time, Precision, error) {
+	t, details, err := ParseDetails(s, defaultLocation, opts...)
+	return t, details.Precision, err
+}
+
+// Details describes the parts of a timestamp that a time.Time can't represent.
+type Details struct {
+	// LeapSecond is whether the timestamp had a :60 leap second.
+	LeapSecond bool
+	// ExcessDigits holds any digits of a fractional second beyond the ninth, which are more precise
+	// than a nanosecond.
+	ExcessDigits string
+	// Precision is the smallest unit given in the timestamp, like PrecisionMonth for 2007-11.  A
+	// fractional hour or minute keeps the precision of the hour or minute.
+	Precision Precision
+	// FractionDigits is the number of digits after the decimal sign, or 0 if there wasn't one.
+	FractionDigits int
+	// Zone says how the timestamp's location was given.
+	Zone ZoneKind
+}
+
+// ZoneKind says how a timestamp's location was given.
+type ZoneKind int
+
+const (
+	// ZoneDefault means the timestamp had no Z or offset, so the default location was used.
+	ZoneDefault ZoneKind = iota + 1
+	// ZoneUTC means the timestamp ended in Z.
+	ZoneUTC
+	// ZoneOffset means the timestamp had a numeric offset like +01:00.
+	ZoneOffset
+	// ZoneUnknownOffset means the timestamp had an offset of -00:00, which RFC 3339 uses for a UTC
+	// time whose local offset is unknown.  The time.Time is in UTC.
+	ZoneUnknownOffset
+	// ZoneNamed means the timestamp had no Z or offset, but did have a location name in brackets like
+	// [America/New_York].
+	ZoneNamed
+)
+
+// String returns the zone kind's name.
+func (k ZoneKind) String() string {
+	switch k {
+	case ZoneDefault:
+		return "default"
+	case ZoneUTC:
+		return "Z"
+	case ZoneOffset:
+		return "offset"
+	case ZoneUnknownOffset:
+		return "unknown offset"
+	case ZoneNamed:
+		return "named"
+	default:
+		return fmt.Sprintf("ZoneKind(%d)", int(k))
+	}
+}
+
+// ParseDetails is like Parse, but also returns the Details that were lost in the time.Time.
+func ParseDetails(s string, defaultLocation *time.Location, opts ...Option) (time.Time, Details, error) {
+	if len(opts) == 0 {
+		return parserFor(defaultLocation).ParseDetails(s)
+	}
+	return NewParser(withLocation(opts, defaultLocation)...).ParseDetails(s)
 }
 
 // ParseUTC takes a string with a ISO 8601 timestamp in it and returns a time.Time.  For inputs
 // that do not specify a location, time.UTC will be used.
-func ParseUTC(s string) (time.Time, error) { return Parse(s, time.UTC) }
+func ParseUTC(s string, opts ...Option) (time.Time, error) {
+	if len(opts) == 0 {
+		return utcParser.Parse(s)
+	}
+	return Parse(s, time.UTC, opts...)
+}
 
 // ParseLocal takes a string with a ISO 8601 timestamp in it and returns a time.Time.  For inputs
 // that do not specify a location, time.Local will be used.
-func ParseLocal(s string) (time.Time, error) { return Parse(s, time.Local) }
+func ParseLocal(s string, opts ...Option) (time.Time, error) {
+	if len(opts) == 0 {
+		return localParser.Parse(s)
+	}
+	return Parse(s, time.Local, opts...)
+}
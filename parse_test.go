@@ -1,7 +1,7 @@
 package datetime
 
 import (
-	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -65,6 +65,22 @@ func TestValidFormats(t *testing.T) {
 			input:       "2007-11-30T10:10:10.000000001",
 			localOutput: time.Date(2007, time.November, 30, 10, 10, 10, 1, time.Local),
 		},
+		{
+			input:       "2007-11-30T10:10:10,123Z",
+			localOutput: time.Date(2007, time.November, 30, 10, 10, 10, 123000000, time.UTC),
+		},
+		{
+			input:       "20071130T101010,000000001",
+			localOutput: time.Date(2007, time.November, 30, 10, 10, 10, 1, time.Local),
+		},
+		{
+			input:       "20071130T1030,25",
+			localOutput: time.Date(2007, time.November, 30, 10, 30, 15, 0, time.Local),
+		},
+		{
+			input:       "2007-11-30T10,5+01:00",
+			localOutput: time.Date(2007, time.November, 30, 10, 30, 0, 0, time.FixedZone("+01:00", 60*60)),
+		},
 		{
 			input:       "2007-11-30T10:10:10.123Z",
 			localOutput: time.Date(2007, time.November, 30, 10, 10, 10, 123000000, time.UTC),
@@ -101,6 +117,38 @@ func TestValidFormats(t *testing.T) {
 			input:       "2007-11-30T10:10:10+00:00",
 			localOutput: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.FixedZone("+00:00", 0)),
 		},
+		{
+			input:       "2007-11-30T10:10-05:00",
+			localOutput: time.Date(2007, time.November, 30, 10, 10, 0, 0, time.FixedZone("-05:00", 60*60*-5)),
+		},
+		{
+			input:       "2007-11-30T10.5Z",
+			localOutput: time.Date(2007, time.November, 30, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			input:       "2007-11-30T10.25",
+			localOutput: time.Date(2007, time.November, 30, 10, 15, 0, 0, time.Local),
+		},
+		{
+			input:       "20071130T10.0001",
+			localOutput: time.Date(2007, time.November, 30, 10, 0, 0, 360000000, time.Local),
+		},
+		{
+			input:       "2007-11-30T10.123456789012345+02:00",
+			localOutput: time.Date(2007, time.November, 30, 10, 7, 24, 444440444, time.FixedZone("+02:00", 60*60*2)),
+		},
+		{
+			input:       "20071130T1030.25",
+			localOutput: time.Date(2007, time.November, 30, 10, 30, 15, 0, time.Local),
+		},
+		{
+			input:       "2007-11-30T10:30.5Z",
+			localOutput: time.Date(2007, time.November, 30, 10, 30, 30, 0, time.UTC),
+		},
+		{
+			input:       "2007-11-30T10:30.000000001",
+			localOutput: time.Date(2007, time.November, 30, 10, 30, 0, 60, time.Local),
+		},
 		{
 			input:       "2007-11-30T10Z",
 			localOutput: time.Date(2007, time.November, 30, 10, 0, 0, 0, time.UTC),
@@ -109,6 +157,70 @@ func TestValidFormats(t *testing.T) {
 			input:       "2007T10",
 			localOutput: time.Date(2007, time.January, 1, 10, 0, 0, 0, time.Local),
 		},
+		{
+			input:       "2007T10:30Z",
+			localOutput: time.Date(2007, time.January, 1, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			input:       "2007-334",
+			localOutput: time.Date(2007, time.November, 30, 0, 0, 0, 0, time.Local),
+		},
+		{
+			input:       "2007334",
+			localOutput: time.Date(2007, time.November, 30, 0, 0, 0, 0, time.Local),
+		},
+		{
+			input:       "2008-366",
+			localOutput: time.Date(2008, time.December, 31, 0, 0, 0, 0, time.Local),
+		},
+		{
+			input:       "2007-001T10:10:10",
+			localOutput: time.Date(2007, time.January, 1, 10, 10, 10, 0, time.Local),
+		},
+		{
+			input:       "2007334T101010Z",
+			localOutput: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC),
+		},
+		{
+			input:       "2007-334T10:10:10+02:00",
+			localOutput: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.FixedZone("+02:00", 60*60*2)),
+		},
+		{
+			input:       "2009-W01-1",
+			localOutput: time.Date(2008, time.December, 29, 0, 0, 0, 0, time.Local),
+		},
+		{
+			input:       "2009W011",
+			localOutput: time.Date(2008, time.December, 29, 0, 0, 0, 0, time.Local),
+		},
+		{
+			input:       "2009-W01",
+			localOutput: time.Date(2008, time.December, 29, 0, 0, 0, 0, time.Local),
+		},
+		{
+			input:       "2009-W53",
+			localOutput: time.Date(2009, time.December, 28, 0, 0, 0, 0, time.Local),
+		},
+		{
+			input:       "2009W537",
+			localOutput: time.Date(2010, time.January, 3, 0, 0, 0, 0, time.Local),
+		},
+		{
+			input:       "2007-W48-5",
+			localOutput: time.Date(2007, time.November, 30, 0, 0, 0, 0, time.Local),
+		},
+		{
+			input:       "2004-W53-6",
+			localOutput: time.Date(2005, time.January, 1, 0, 0, 0, 0, time.Local),
+		},
+		{
+			input:       "2007-W48-5T10:10:10Z",
+			localOutput: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC),
+		},
+		{
+			input:       "2007W485T101010+0100",
+			localOutput: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.FixedZone("+0100", 60*60)),
+		},
 	}
 
 	for _, tc := range tt {
@@ -133,7 +245,55 @@ func TestErrors(t *testing.T) {
 		},
 		{
 			input: "20077",
-			err:   "found 20077, expected yyyy-mm-dd or yyyymmdd",
+			err:   "found 20077, expected yyyy-mm-dd, yyyymmdd, yyyy-ddd, or yyyyddd",
+		},
+		{
+			input: "2007-366",
+			err:   "366 is not a valid day of the year in 2007",
+		},
+		{
+			input: "2008-367",
+			err:   "367 is not a valid day of the year in 2008",
+		},
+		{
+			input: "2007000",
+			err:   "000 is not a valid day of the year in 2007",
+		},
+		{
+			input: "2010-W53",
+			err:   "53 is not a valid week in 2010",
+		},
+		{
+			input: "2009-W00-1",
+			err:   "00 is not a valid week in 2009",
+		},
+		{
+			input: "2009-W01-8",
+			err:   "8 is not a valid day of the week",
+		},
+		{
+			input: "2009-W011",
+			err:   "found 011, expected ww",
+		},
+		{
+			input: "2009W01-1",
+			err:   "found -, expected T or EOF",
+		},
+		{
+			input: "2009W0111",
+			err:   "found 0111, expected ww or wwd",
+		},
+		{
+			input: "2009-W01-12",
+			err:   "found 12, expected day of the week",
+		},
+		{
+			input: "2009-WQ",
+			err:   "found Q, expected week number",
+		},
+		{
+			input: "2007-334Q",
+			err:   "found Q, expected T or EOF",
 		},
 		{
 			input: "2007-13",
@@ -143,6 +303,18 @@ func TestErrors(t *testing.T) {
 			input: "2007-11-31",
 			err:   "31 is not a valid day in November",
 		},
+		{
+			input: "2007-02-29",
+			err:   "29 is not a valid day in February 2007",
+		},
+		{
+			input: "1900-02-29",
+			err:   "29 is not a valid day in February 1900",
+		},
+		{
+			input: "20070229T10:10:10Z",
+			err:   "29 is not a valid day in February 2007",
+		},
 		{
 			input: "2007-11-30TA",
 			err:   "expected number. got A",
@@ -159,6 +331,22 @@ func TestErrors(t *testing.T) {
 			input: "2007-11-30T12:C",
 			err:   "found C, expected number",
 		},
+		{
+			input: "2007-11-30T10.Z",
+			err:   "expected fraction of hours.  got Z",
+		},
+		{
+			input: "2007-11-30T10:30.",
+			err:   "expected fraction of minutes.  got ",
+		},
+		{
+			input: "2007-11-30T10.5:30",
+			err:   "expected Z, timezone offset, or EOF. got :",
+		},
+		{
+			input: "2007-11-30T10:30.5:30",
+			err:   "expected Z, timezone offset, or EOF. got :",
+		},
 		{
 			input: "2007-11-10T25",
 			err:   "25 is not a valid hour",
@@ -179,13 +367,21 @@ func TestErrors(t *testing.T) {
 			input: "2007-11-30T12:11:20.",
 			err:   "expected fraction of seconds.  got ",
 		},
+		{
+			input: "2007-11-30T12:11:20,",
+			err:   "expected fraction of seconds.  got ",
+		},
+		{
+			input: "2007-11-30T12:11:20,456Q",
+			err:   "expected Z, timezone offset, or EOF. got Q",
+		},
 		{
 			input: "2007-11-30T12:11:20.456Q",
 			err:   "expected Z, timezone offset, or EOF. got Q",
 		},
 		{
 			input: "2007-11-30T12:11:20.456+111",
-			err:   "expected ±hh:mm, ±hhmm, or ±hh timezone offset format. got 111",
+			err:   "expected ±hh:mm:ss, ±hh:mm, ±hhmmss, ±hhmm, or ±hh timezone offset format. got 111",
 		},
 		{
 			input: "2007-11-30T12:11:20.456+Q",
@@ -209,11 +405,15 @@ func TestErrors(t *testing.T) {
 		},
 		{
 			input: "2007Q",
-			err:   "found Q, expected dash, T, or EOF",
+			err:   "found Q, expected dash, W, T, or EOF",
 		},
 		{
 			input: "2007-11Q",
-			err:   "found Q, expected dash, T, or EOF",
+			err:   "found Q, expected dash or EOF",
+		},
+		{
+			input: "2007-11T10",
+			err:   "found T, expected dash or EOF",
 		},
 		{
 			input: "2007-11-Q",
@@ -228,7 +428,351 @@ func TestErrors(t *testing.T) {
 	for _, tc := range tt {
 		when, err := ParseLocal(tc.input)
 		assert.Equal(t, zeroTime, when, tc.input)
-		assert.Equal(t, errors.New(tc.err), err, tc.input)
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+}
+
+func TestLeapYears(t *testing.T) {
+	tt := []struct {
+		input  string
+		output time.Time
+	}{
+		{
+			input:  "2008-02-29",
+			output: time.Date(2008, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "2000-02-29",
+			output: time.Date(2000, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tt {
+		ts, err := ParseUTC(tc.input)
+		assert.Equal(t, tc.output, ts, tc.input)
+		assert.Nil(t, err, tc.input)
+	}
+
+	// the Lenient option allows February 29th in any year, and lets time.Date normalize it.
+	ts, err := ParseUTC("2007-02-29", Lenient())
+	assert.Equal(t, time.Date(2007, time.March, 1, 0, 0, 0, 0, time.UTC), ts)
+	assert.Nil(t, err)
+
+	_, err = ParseUTC("2007-02-30", Lenient())
+	assert.EqualError(t, err, "30 is not a valid day in February")
+}
+
+func TestEndOfDayAndLeapSeconds(t *testing.T) {
+	tt := []struct {
+		input  string
+		output time.Time
+		leap   bool
+	}{
+		{
+			input:  "2007-12-31T24:00",
+			output: time.Date(2008, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-30T24:00:00.0Z",
+			output: time.Date(2007, time.December, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "20071130T240000",
+			output: time.Date(2007, time.December, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "2008-12-31T23:59:60Z",
+			output: time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC),
+			leap:   true,
+		},
+		{
+			input:  "2008-12-31T23:59:60.5Z",
+			output: time.Date(2009, time.January, 1, 0, 0, 0, 500000000, time.UTC),
+			leap:   true,
+		},
+		{
+			input:  "2009-01-01T00:59:60+01:00",
+			output: time.Date(2009, time.January, 1, 1, 0, 0, 0, time.FixedZone("+01:00", 60*60)),
+			leap:   true,
+		},
+		{
+			input:  "2008-12-31T23:59:59Z",
+			output: time.Date(2008, time.December, 31, 23, 59, 59, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tt {
+		ts, leap, err := ParseWithLeapSecond(tc.input, time.UTC)
+		assert.Equal(t, tc.output, ts, tc.input)
+		assert.Equal(t, tc.leap, leap, tc.input)
+		assert.Nil(t, err, tc.input)
+	}
+
+	errs := []struct {
+		input string
+		opts  []Option
+		err   string
+	}{
+		{
+			input: "2007-11-30T24:00:01",
+			err:   "24 is not a valid hour",
+		},
+		{
+			input: "2007-11-30T24:30",
+			err:   "24 is not a valid hour",
+		},
+		{
+			input: "2007-11-30T24:00:00.1",
+			err:   "24 is not a valid hour",
+		},
+		{
+			input: "2008-12-31T23:58:60Z",
+			err:   "60 is not a valid second",
+		},
+		{
+			input: "2008-12-31T23:59:60Z",
+			opts:  []Option{RejectLeapSeconds()},
+			err:   "60 is not a valid second",
+		},
+	}
+
+	for _, tc := range errs {
+		ts, err := ParseUTC(tc.input, tc.opts...)
+		assert.Equal(t, zeroTime, ts, tc.input)
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+}
+
+func TestFractionalSeconds(t *testing.T) {
+	tt := []struct {
+		input  string
+		opts   []Option
+		output time.Time
+		excess string
+	}{
+		{
+			input:  "2007-11-30T10:10:10.1234567894",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 123456789, time.UTC),
+			excess: "4",
+		},
+		{
+			input:  "2007-11-30T10:10:10.1234567895",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 123456790, time.UTC),
+			excess: "5",
+		},
+		{
+			input:  "2007-11-30T10:10:10.1234567895",
+			opts:   []Option{TruncateFractions()},
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 123456789, time.UTC),
+			excess: "5",
+		},
+		{
+			input:  "2007-11-30T10:10:10.123456789123456789123456789Z",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 123456789, time.UTC),
+			excess: "123456789123456789",
+		},
+		{
+			input:  "2007-11-30T10:10:10.9999999999",
+			output: time.Date(2007, time.November, 30, 10, 10, 11, 0, time.UTC),
+			excess: "9",
+		},
+		{
+			input:  "2007-11-30T10:10:10.9999999999",
+			opts:   []Option{TruncateFractions()},
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 999999999, time.UTC),
+			excess: "9",
+		},
+		{
+			input:  "2007-11-30T10:10:10.000000000000000000000000001",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC),
+			excess: "000000000000000001",
+		},
+		{
+			input:  "2007-11-30T10:10:10.123",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 123000000, time.UTC),
+		},
+	}
+
+	for _, tc := range tt {
+		ts, details, err := ParseDetails(tc.input, time.UTC, tc.opts...)
+		assert.Equal(t, tc.output, ts, tc.input)
+		assert.Equal(t, tc.excess, details.ExcessDigits, tc.input)
+		assert.Nil(t, err, tc.input)
+	}
+}
+
+func TestZoneAfterMinutes(t *testing.T) {
+	// a zone right after the minutes used to be dropped, leaving the default location.
+	tt := []struct {
+		input  string
+		output time.Time
+	}{
+		{
+			input:  "2007-11-30T10:10Z",
+			output: time.Date(2007, time.November, 30, 10, 10, 0, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-30T10:10-05:00",
+			output: time.Date(2007, time.November, 30, 10, 10, 0, 0, time.FixedZone("-05:00", -5*60*60)),
+		},
+		{
+			input:  "20071130T1010+0530",
+			output: time.Date(2007, time.November, 30, 10, 10, 0, 0, time.FixedZone("+0530", 5*60*60+30*60)),
+		},
+	}
+
+	for _, tc := range tt {
+		ts, details, err := ParseDetails(tc.input, time.Local)
+		assert.Nil(t, err, tc.input)
+		assert.Equal(t, tc.output, ts, tc.input)
+		assert.NotEqual(t, ZoneDefault, details.Zone, tc.input)
+	}
+}
+
+func TestFractionCarry(t *testing.T) {
+	// fractions of an hour or minute that round up carry into the next unit.
+	tt := []struct {
+		input  string
+		opts   []Option
+		output time.Time
+	}{
+		{
+			input:  "2007-11-30T10:30.99999999999999Z",
+			output: time.Date(2007, time.November, 30, 10, 31, 0, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-30T10.99999999999999Z",
+			output: time.Date(2007, time.November, 30, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-30T23.99999999999999Z",
+			output: time.Date(2007, time.December, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-30T10:59.99999999999999Z",
+			output: time.Date(2007, time.November, 30, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-30T10.99999999999999Z",
+			opts:   []Option{TruncateFractions()},
+			output: time.Date(2007, time.November, 30, 10, 59, 59, 999999999, time.UTC),
+		},
+	}
+
+	for _, tc := range tt {
+		ts, err := ParseUTC(tc.input, tc.opts...)
+		assert.Nil(t, err, tc.input)
+		assert.Equal(t, tc.output, ts, tc.input)
+	}
+
+	tod, err := ParseTimeOfDay("T10:59.99999999999999")
+	assert.Nil(t, err)
+	assert.Equal(t, TimeOfDay{Hour: 11}, tod)
+
+	tod, err = ParseTimeOfDay("T10:10:59.9999999999")
+	assert.Nil(t, err)
+	assert.Equal(t, TimeOfDay{Hour: 10, Minute: 11}, tod)
+}
+
+func TestExpandedYears(t *testing.T) {
+	tt := []struct {
+		input  string
+		extra  int
+		output time.Time
+	}{
+		{
+			input:  "+012007-11-30",
+			extra:  2,
+			output: time.Date(12007, time.November, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "-000044-03-15",
+			extra:  2,
+			output: time.Date(-44, time.March, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "+000000-01-01T10:10:10Z",
+			extra:  2,
+			output: time.Date(0, time.January, 1, 10, 10, 10, 0, time.UTC),
+		},
+		{
+			input:  "-000440315",
+			extra:  1,
+			output: time.Date(-44, time.March, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "-000044-074",
+			extra:  2,
+			output: time.Date(-44, time.March, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "-000044-02-29",
+			extra:  2,
+			output: time.Date(-44, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "+12345678901-W01-1",
+			extra:  7,
+			output: time.Date(12345678901, time.January, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-30",
+			extra:  2,
+			output: time.Date(2007, time.November, 30, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tt {
+		ts, err := ParseUTC(tc.input, ExpandedYears(tc.extra))
+		assert.Equal(t, tc.output, ts, tc.input)
+		assert.Nil(t, err, tc.input)
+	}
+
+	errs := []struct {
+		input string
+		opts  []Option
+		err   string
+	}{
+		{
+			input: "+012007-11-30",
+			err:   "found +, expected number",
+		},
+		{
+			input: "+2007-11-30",
+			opts:  []Option{ExpandedYears(2)},
+			err:   "found 2007, expected 6 digit year",
+		},
+		{
+			input: "+01200711",
+			opts:  []Option{ExpandedYears(2)},
+			err:   "found 01200711, expected 6 digit year",
+		},
+		{
+			input: "-000045-02-29",
+			opts:  []Option{ExpandedYears(2)},
+			err:   "29 is not a valid day in February -45",
+		},
+		{
+			input: "+999999999999999",
+			opts:  []Option{ExpandedYears(11)},
+			err:   "year 999999999999999 is outside the range time.Time supports",
+		},
+		{
+			input: "+9999999999999999999-01-01",
+			opts:  []Option{ExpandedYears(15)},
+			err:   "year 9999999999999999999 is outside the range time.Time supports",
+		},
+		{
+			input: "-9999999999999999999-01-01",
+			opts:  []Option{ExpandedYears(15)},
+			err:   "year -9999999999999999999 is outside the range time.Time supports",
+		},
+	}
+
+	for _, tc := range errs {
+		ts, err := ParseUTC(tc.input, tc.opts...)
+		assert.Equal(t, zeroTime, ts, tc.input)
+		assert.EqualError(t, err, tc.err, tc.input)
 	}
 }
 
@@ -238,13 +782,281 @@ func TestParseInt(t *testing.T) {
 	assert.Panics(t, func() { parseInt("") })
 }
 
-func TestRound(t *testing.T) {
-	assert.Equal(t, 1.0, round(0.9))
-}
-
 func TestParseUTC(t *testing.T) {
 	// just one test case, since this is just a wrapper
 	ts, err := ParseUTC("2007")
 	assert.Equal(t, time.Date(2007, time.January, 1, 0, 0, 0, 0, time.UTC), ts)
 	assert.Nil(t, err)
 }
+
+func TestParser(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	tt := []struct {
+		input  string
+		opts   []Option
+		output time.Time
+	}{
+		{
+			input:  "2007-11-30 10:10",
+			opts:   []Option{Separators('T', 't', ' ')},
+			output: time.Date(2007, time.November, 30, 10, 10, 0, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-30t10:10",
+			opts:   []Option{Separators('T', 't', ' ')},
+			output: time.Date(2007, time.November, 30, 10, 10, 0, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-30T10:10:10Z",
+			opts:   []Option{RequireOffset()},
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-30T10:10",
+			opts:   []Option{DefaultLocation(est)},
+			output: time.Date(2007, time.November, 30, 10, 10, 0, 0, est),
+		},
+		{
+			input:  "2007-11-30T10:10:10.5",
+			opts:   []Option{Precisions(PrecisionSecond, PrecisionSubsecond)},
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 500000000, time.UTC),
+		},
+	}
+
+	for _, tc := range tt {
+		ts, err := NewParser(tc.opts...).Parse(tc.input)
+		assert.Equal(t, tc.output, ts, tc.input)
+		assert.Nil(t, err, tc.input)
+	}
+
+	errs := []struct {
+		input string
+		opts  []Option
+		err   string
+	}{
+		{
+			input: "2007-11-30 10:10",
+			err:   "found  , expected T or EOF",
+		},
+		{
+			input: "2007-11-30t10:10",
+			opts:  []Option{Separators('T', ' ')},
+			err:   "found t, expected T, space, or EOF",
+		},
+		{
+			input: "20071130Q",
+			err:   "found Q, expected T or EOF",
+		},
+		{
+			input: "2007-11-30T10:10",
+			opts:  []Option{RequireOffset()},
+			err:   "found , expected Z or timezone offset",
+		},
+		{
+			input: "2007-11-30",
+			opts:  []Option{RequireOffset()},
+			err:   "found , expected T",
+		},
+		{
+			input: "2007-11-30T10:10Z",
+			opts:  []Option{Precisions(PrecisionSecond, PrecisionSubsecond)},
+			err:   "minute precision is not allowed",
+		},
+		{
+			input: "2007-W48",
+			opts:  []Option{Precisions(PrecisionDay)},
+			err:   "week precision is not allowed",
+		},
+	}
+
+	for _, tc := range errs {
+		ts, err := NewParser(tc.opts...).Parse(tc.input)
+		assert.Equal(t, zeroTime, ts, tc.input)
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+}
+
+func TestParserConcurrent(t *testing.T) {
+	p := NewParser(Separators('T', ' '), RequireOffset())
+	want := time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				ts, err := p.Parse("2007-11-30 10:10:10Z")
+				assert.Equal(t, want, ts)
+				assert.Nil(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestParseDefaultLocationWins(t *testing.T) {
+	// the location passed to Parse overrides any DefaultLocation option.
+	ts, err := Parse("2007-11-30T10:10", time.UTC, DefaultLocation(time.FixedZone("EST", -5*60*60)))
+	assert.Equal(t, time.Date(2007, time.November, 30, 10, 10, 0, 0, time.UTC), ts)
+	assert.Nil(t, err)
+}
+
+func TestParseWithPrecision(t *testing.T) {
+	tt := []struct {
+		input          string
+		precision      Precision
+		fractionDigits int
+		zone           ZoneKind
+	}{
+		{input: "2007", precision: PrecisionYear, zone: ZoneDefault},
+		{input: "2007-11", precision: PrecisionMonth, zone: ZoneDefault},
+		{input: "2007-W48", precision: PrecisionWeek, zone: ZoneDefault},
+		{input: "2007-W48-5", precision: PrecisionDay, zone: ZoneDefault},
+		{input: "2007-334", precision: PrecisionDay, zone: ZoneDefault},
+		{input: "20071130", precision: PrecisionDay, zone: ZoneDefault},
+		{input: "2007-11-30T10Z", precision: PrecisionHour, zone: ZoneUTC},
+		{input: "2007-11-30T10.5", precision: PrecisionHour, fractionDigits: 1, zone: ZoneDefault},
+		{input: "2007-11-30T10:10+01:00", precision: PrecisionMinute, zone: ZoneOffset},
+		{input: "20071130T101010-05", precision: PrecisionSecond, zone: ZoneOffset},
+		{input: "2007-11-30T10:10:10,123Z", precision: PrecisionSubsecond, fractionDigits: 3, zone: ZoneUTC},
+	}
+
+	for _, tc := range tt {
+		_, precision, err := ParseWithPrecision(tc.input, time.UTC)
+		assert.Nil(t, err, tc.input)
+		assert.Equal(t, tc.precision, precision, tc.input)
+
+		_, details, err := ParseDetails(tc.input, time.UTC)
+		assert.Nil(t, err, tc.input)
+		assert.Equal(t, tc.precision, details.Precision, tc.input)
+		assert.Equal(t, tc.fractionDigits, details.FractionDigits, tc.input)
+		assert.Equal(t, tc.zone, details.Zone, tc.input)
+	}
+
+	// the profiles report the same details.
+	_, details, err := NewParser(RFC3339()).ParseDetails("2007-11-30T10:10:10.50Z")
+	assert.Nil(t, err)
+	assert.Equal(t, Details{Precision: PrecisionSubsecond, FractionDigits: 2, Zone: ZoneUTC}, details)
+
+	_, details, err = NewParser(XSDGYearMonth()).ParseDetails("2007-11+01:00")
+	assert.Nil(t, err)
+	assert.Equal(t, Details{Precision: PrecisionMonth, Zone: ZoneOffset}, details)
+
+	_, precision, err := ParseWithPrecision("2007-13", time.UTC)
+	assert.EqualError(t, err, "13 is not a valid month")
+	assert.Equal(t, Precision(0), precision)
+}
+
+func TestZoneKindString(t *testing.T) {
+	assert.Equal(t, "default", ZoneDefault.String())
+	assert.Equal(t, "Z", ZoneUTC.String())
+	assert.Equal(t, "offset", ZoneOffset.String())
+	assert.Equal(t, "unknown offset", ZoneUnknownOffset.String())
+	assert.Equal(t, "named", ZoneNamed.String())
+	assert.Equal(t, "ZoneKind(0)", ZoneKind(0).String())
+}
+
+func TestOffsets(t *testing.T) {
+	tt := []struct {
+		input  string
+		opts   []Option
+		output time.Time
+		zone   ZoneKind
+	}{
+		{
+			input:  "1937-06-30T10:10:10+00:19:32",
+			output: time.Date(1937, time.June, 30, 10, 10, 10, 0, time.FixedZone("+00:19:32", 19*60+32)),
+			zone:   ZoneOffset,
+		},
+		{
+			input:  "19370630T101010+001932",
+			output: time.Date(1937, time.June, 30, 10, 10, 10, 0, time.FixedZone("+001932", 19*60+32)),
+			zone:   ZoneOffset,
+		},
+		{
+			input:  "2007-11-30T10:10:10+18:00",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.FixedZone("+18:00", 18*60*60)),
+			zone:   ZoneOffset,
+		},
+		{
+			input:  "2007-11-30T10:10:10+00:00",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.FixedZone("+00:00", 0)),
+			zone:   ZoneOffset,
+		},
+		{
+			input:  "2007-11-30T10:10:10-00:00",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC),
+			zone:   ZoneUnknownOffset,
+		},
+		{
+			input:  "2007-11-30T10:10:10-0000",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC),
+			zone:   ZoneUnknownOffset,
+		},
+		{
+			input:  "2007-11-30T10:10:10-00:00",
+			opts:   []Option{RFC3339()},
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC),
+			zone:   ZoneUnknownOffset,
+		},
+		{
+			input:  "2007-11-30T10:10:10-00:00",
+			opts:   []Option{XSDDateTime()},
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.FixedZone("-00:00", 0)),
+			zone:   ZoneOffset,
+		},
+		{
+			input:  "2007-11-30T10:10:10+23:00",
+			opts:   []Option{MaxOffset(24 * time.Hour)},
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.FixedZone("+23:00", 23*60*60)),
+			zone:   ZoneOffset,
+		},
+		{
+			input:  "2007-11-30T10:10:10-23:59",
+			opts:   []Option{RFC3339(), MaxOffset(24 * time.Hour)},
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.FixedZone("-23:59", -(23*60*60+59*60))),
+			zone:   ZoneOffset,
+		},
+	}
+
+	for _, tc := range tt {
+		ts, details, err := NewParser(tc.opts...).ParseDetails(tc.input)
+		assert.Nil(t, err, tc.input)
+		assert.Equal(t, tc.output, ts, tc.input)
+		assert.Equal(t, tc.zone, details.Zone, tc.input)
+	}
+
+	errs := []struct {
+		input string
+		opts  []Option
+		err   string
+	}{
+		{input: "2007-11-30T10:10:10+99:99", err: "+99:99 is not a valid timezone offset"},
+		{input: "2007-11-30T10:10:10+0560", err: "+0560 is not a valid timezone offset"},
+		{input: "2007-11-30T10:10:10+05:00:60", err: "+05:00:60 is not a valid timezone offset"},
+		{input: "2007-11-30T10:10:10+18:01", err: "+18:01 is more than 18:00 from UTC"},
+		{input: "2007-11-30T10:10:10-19", err: "-19 is more than 18:00 from UTC"},
+		{input: "2007-11-30T10:10:10+14:00", opts: []Option{MaxOffset(12 * time.Hour)}, err: "+14:00 is more than 12:00 from UTC"},
+		{input: "2007-11-30T10:10:10+20:00", opts: []Option{RFC3339()}, err: "+20:00 is more than 18:00 from UTC"},
+		{input: "2007-11-30T10:10:10+20:00", opts: []Option{RFC3339(), MaxOffset(19 * time.Hour)}, err: "+20:00 is more than 19:00 from UTC"},
+		{input: "2007-11-30T10:10:10+05:3", err: "expected 2 digit minutes. got 3"},
+		{input: "2007-11-30T10:10:10+05:30:1", err: "expected 2 digit seconds. got 1"},
+		{input: "2007-11-30T10:10:10+05:30:Q", err: "expected number. got Q"},
+		{input: "2007-11-30T10:10:10+0530:00", err: "expected EOF. got :"},
+	}
+
+	for _, tc := range errs {
+		ts, err := NewParser(tc.opts...).Parse(tc.input)
+		assert.Equal(t, zeroTime, ts, tc.input)
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+
+	perr := func() *ParseError {
+		_, err := ParseUTC("2007-11-30T10:10:10+99:99")
+		return err.(*ParseError)
+	}()
+	assert.Equal(t, 19, perr.Offset)
+	assert.Equal(t, "+99:99", perr.Literal)
+	assert.Equal(t, RangeError, perr.Category)
+}
@@ -1,28 +1,41 @@
 package datetime
 
 import (
+	"bytes"
 	"fmt"
-	"io"
-	"math"
 	"strconv"
 	"time"
 )
 
-const nsecsPerSec = 1000000000
-
 var zeroTime = time.Time{}
 
 type parser struct {
-	s   *scanner
-	buf struct {
+	s     *scanner
+	input []byte
+	opts  options
+	buf   struct {
 		tok token  // last read token
 		lit string // last read literal
+		pos int    // byte offset of the last read token
 		n   int    // buffer size (max=1)
 	}
+
+	details Details // what the parsed timestamp had that a time.Time can't represent
+	fields  fields  // where the date and time fields were found, for range errors
+}
+
+// field is where a date or time field was found in the input.
+type field struct {
+	offset int
+	lit    string
 }
 
-func newParser(r io.Reader) *parser {
-	return &parser{s: newScanner(r)}
+type fields struct {
+	month, day, hour, min, sec, zone field
+}
+
+func newParser(input []byte, opts options) *parser {
+	return &parser{s: newScanner(bytes.NewReader(input)), input: input, opts: opts}
 }
 
 // scan returns the next token from the underlying scanner.
@@ -38,7 +51,7 @@ func (p *parser) scan() (tok token, lit string) {
 	tok, lit = p.s.scan()
 
 	// Save it to the buffer in case we unscan later.
-	p.buf.tok, p.buf.lit = tok, lit
+	p.buf.tok, p.buf.lit, p.buf.pos = tok, lit, p.s.start
 
 	return
 }
@@ -46,25 +59,78 @@ func (p *parser) scan() (tok token, lit string) {
 // unscan pushes the previously read token back onto the buffer.
 func (p *parser) unscan() { p.buf.n = 1 }
 
-func (p *parser) parse(defaultLocation *time.Location) (time.Time, error) {
-	location := defaultLocation
+// last returns where the last read token was found, optionally skipping some of its leading bytes
+// and keeping only n of the rest.  A negative n keeps all of them.
+func (p *parser) last(skip, n int) field {
+	lit := p.buf.lit[skip:]
+	if n >= 0 {
+		lit = lit[:n]
+	}
+	return field{offset: p.buf.pos + skip, lit: lit}
+}
+
+// errorAt returns a ParseError for a problem with the given field.
+func (p *parser) errorAt(category ErrorCategory, f field, expected []string, format string, args ...interface{}) error {
+	return &ParseError{
+		Input:    string(p.input),
+		Offset:   f.offset,
+		Literal:  f.lit,
+		Expected: expected,
+		Category: category,
+		msg:      fmt.Sprintf(format, args...),
+	}
+}
+
+// syntaxError returns a ParseError for the last read token, which wasn't one of the expected ones.
+func (p *parser) syntaxError(expected []string, format string, args ...interface{}) error {
+	return p.errorAt(SyntaxError, p.last(0, -1), expected, format, args...)
+}
+
+// unexpected returns a syntax error in the "found x, expected y" form.
+func (p *parser) unexpected(expected ...string) error {
+	return p.syntaxError(expected, "found %s, expected %s", p.buf.lit, orList(expected))
+}
+
+// unsupported returns a ParseError for the last read token, which is in a format that isn't
+// supported.
+func (p *parser) unsupported(expected []string, format string, args ...interface{}) error {
+	return p.errorAt(UnsupportedError, p.last(0, -1), expected, format, args...)
+}
+
+func (p *parser) parse() (time.Time, error) {
+	switch p.opts.profile {
+	case profileISO8601:
+	case profileRFC3339:
+		return p.parseRFC3339()
+	default:
+		return p.parseXMLSchema()
+	}
+
 	year, month, day, err := p.parseDate()
 	if err != nil {
 		return zeroTime, err
 	}
 
-	var hour, min, sec, nsec int
-	// parseDate has already checked that there's a T or EOF next, so those are the only cases we need
-	// to check here.
+	var hour, min, sec int
+	var frac time.Duration
+	location := p.opts.location
 
-	switch tok, _ := p.scan(); tok {
+	switch tok, lit := p.scan(); tok {
 	case EOF:
-		return buildTime(year, month, day, hour, min, sec, nsec, location)
+		if p.opts.requireOffset {
+			return zeroTime, p.unexpected(p.separatorNames()...)
+		}
+		return p.buildTime(year, month, day, hour, min, sec, frac, location)
 	case T:
-		hour, min, sec, nsec, err = p.parseTime()
+		if !p.opts.separator(lit) {
+			return zeroTime, p.unexpected(append(p.separatorNames(), "EOF")...)
+		}
+		hour, min, sec, frac, err = p.parseTime()
 		if err != nil {
 			return zeroTime, err
 		}
+	default:
+		return zeroTime, p.unexpected(append(p.separatorNames(), "EOF")...)
 	}
 
 	location, err = p.parseLocation(location)
@@ -72,79 +138,149 @@ func (p *parser) parse(defaultLocation *time.Location) (time.Time, error) {
 		return zeroTime, err
 	}
 
+	named, err := p.parseSuffix()
+	if err != nil {
+		return zeroTime, err
+	}
+	if named != nil && p.details.Zone == 0 {
+		// without an offset, the named location is where the wall clock time is.
+		location = named
+		p.details.Zone = ZoneNamed
+	}
+
 	// there should be nothing left at this point
 	if tok, lit := p.scan(); tok != EOF {
-		return zeroTime, fmt.Errorf("expected EOF. got %s", lit)
+		return zeroTime, p.syntaxError([]string{"EOF"}, "expected EOF. got %s", lit)
 	}
 
-	return buildTime(year, month, day, hour, min, sec, nsec, location)
+	t, err := p.buildTime(year, month, day, hour, min, sec, frac, location)
+	if err != nil || named == nil {
+		return t, err
+	}
+	return p.inNamedLocation(t, named)
+}
+
+// separatorNames lists the allowed date and time separators, for error messages.
+func (p *parser) separatorNames() []string {
+	var names []string
+	for _, r := range p.opts.separators {
+		if r == ' ' {
+			names = append(names, "space")
+		} else {
+			names = append(names, string(r))
+		}
+	}
+	return names
 }
 
 func (p *parser) parseLocation(defaultLocation *time.Location) (*time.Location, error) {
-	var sign, secs int
-	var name string
+	sign := 1
 	switch tok, lit := p.scan(); tok {
-	case EOF:
+	case EOF, LBRACKET:
+		if p.opts.requireOffset {
+			return nil, p.unexpected("Z", "timezone offset")
+		}
+		if tok == LBRACKET {
+			p.unscan()
+		}
 		return defaultLocation, nil
 	case Z:
+		if lit != "Z" {
+			return nil, p.syntaxError([]string{"Z", "+", "-", "EOF"}, "expected Z, timezone offset, or EOF. got %s", lit)
+		}
+		p.details.Zone = ZoneUTC
+		p.fields.zone = p.last(0, -1)
 		return time.UTC, nil
 	case PLUS:
-		sign = 1
-		name += lit
 	case DASH:
 		sign = -1
-		name += lit
 	default:
-		return nil, fmt.Errorf("expected Z, timezone offset, or EOF. got %s", lit)
+		return nil, p.syntaxError([]string{"Z", "+", "-", "EOF"}, "expected Z, timezone offset, or EOF. got %s", lit)
 	}
+	start := p.buf.pos
 
-	switch tok, lit := p.scan(); tok {
-	case NUMBER:
-		name += lit
-		switch len(lit) {
-		case 4:
-			hours := parseInt(lit[:2])
-			secs = hours * 60 * 60
-			minutes := parseInt(lit[2:4])
-			secs += minutes * 60
-		case 2:
-			hours := parseInt(lit)
-			secs = hours * 60 * 60
+	var hours, minutes, seconds int
+	tok, lit := p.scan()
+	if tok != NUMBER {
+		return nil, p.syntaxError([]string{"number"}, "expected number. got %s", lit)
+	}
+	end := p.buf.pos + len(lit)
+	switch len(lit) {
+	case 2:
+		// the extended format, where the minutes and seconds are optional.
+		hours = parseInt(lit)
+		switch tok, lit := p.scan(); tok {
+		case EOF, LBRACKET:
+			p.unscan()
+		case COLON:
+			var err error
+			if minutes, end, err = p.scanOffsetPart("minutes"); err != nil {
+				return nil, err
+			}
+			if tok, _ := p.scan(); tok != COLON {
+				p.unscan()
+			} else if seconds, end, err = p.scanOffsetPart("seconds"); err != nil {
+				return nil, err
+			}
 		default:
-			return nil, fmt.Errorf("expected ±hh:mm, ±hhmm, or ±hh timezone offset format. got %s", lit)
+			return nil, p.syntaxError([]string{":", "EOF"}, "expected colon or EOF. got %s", lit)
 		}
+	case 4:
+		hours = parseInt(lit[:2])
+		minutes = parseInt(lit[2:4])
+	case 6:
+		hours = parseInt(lit[:2])
+		minutes = parseInt(lit[2:4])
+		seconds = parseInt(lit[4:6])
 	default:
-		return nil, fmt.Errorf("expected number. got %s", lit)
+		return nil, p.unsupported([]string{"hh:mm:ss", "hh:mm", "hhmmss", "hhmm", "hh"}, "expected ±hh:mm:ss, ±hh:mm, ±hhmmss, ±hhmm, or ±hh timezone offset format. got %s", lit)
 	}
 
-	switch tok, lit := p.scan(); tok {
-	case EOF:
-		return time.FixedZone(name, sign*secs), nil
-	default:
-		if tok != COLON {
-			return nil, fmt.Errorf("expected colon or EOF. got %s", lit)
-		}
-	}
-	name += ":"
+	return p.offsetLocation(sign, hours, minutes, seconds, field{offset: start, lit: string(p.input[start:end])}, p.opts.maxOffset)
+}
 
-	switch tok, lit := p.scan(); tok {
-	case NUMBER:
-		name += lit
-		minutes := parseInt(lit)
-		secs += minutes * 60
-	default:
-		return nil, fmt.Errorf("expected number. got %s", lit)
+// scanOffsetPart reads the two digit minutes or seconds of an offset after a colon, and returns it
+// along with the byte offset where it ends.
+func (p *parser) scanOffsetPart(name string) (int, int, error) {
+	tok, lit := p.scan()
+	if tok != NUMBER {
+		return 0, 0, p.syntaxError([]string{"number"}, "expected number. got %s", lit)
+	}
+	if len(lit) != 2 {
+		return 0, 0, p.unsupported([]string{"2 digit " + name}, "expected 2 digit %s. got %s", name, lit)
 	}
+	return parseInt(lit), p.buf.pos + len(lit), nil
+}
 
-	return time.FixedZone(name, sign*secs), nil
+// offsetLocation checks the offset in the given field, and returns it as a location.  An offset of
+// -00:00 is how RFC 3339 writes a UTC time whose local offset is unknown, so it's returned as UTC.  XML
+// Schema treats it as just another way to write UTC.
+func (p *parser) offsetLocation(sign, hours, minutes, seconds int, f field, max time.Duration) (*time.Location, error) {
+	p.fields.zone = f
+	if minutes > 59 || seconds > 59 {
+		return nil, p.errorAt(RangeError, f, nil, "%s is not a valid timezone offset", f.lit)
+	}
+	secs := hours*60*60 + minutes*60 + seconds
+	if time.Duration(secs)*time.Second > max {
+		return nil, p.errorAt(RangeError, f, nil, "%s is more than %02d:%02d from UTC", f.lit, int(max/time.Hour), int(max%time.Hour/time.Minute))
+	}
+	xmlSchema := p.opts.profile != profileISO8601 && p.opts.profile != profileRFC3339
+	if sign < 0 && secs == 0 && !xmlSchema {
+		p.details.Zone = ZoneUnknownOffset
+		return time.UTC, nil
+	}
+	p.details.Zone = ZoneOffset
+	return time.FixedZone(f.lit, sign*secs), nil
 }
 
-// parseTime returns the hour, minute, second, and nanosecond in the timestamp.
-func (p *parser) parseTime() (int, int, int, int, error) {
+// parseTime reads a time, and returns its hour, minute, and second, and any fraction of the last of
+// them.
+func (p *parser) parseTime() (int, int, int, time.Duration, error) {
 	var minParsed, secParsed bool
-	var hour, min, sec, nsec int
+	var hour, min, sec int
+	var frac time.Duration
 	var err error
-	parseErr := func(err error) (int, int, int, int, error) {
+	parseErr := func(err error) (int, int, int, time.Duration, error) {
 		return 0, 0, 0, 0, err
 	}
 
@@ -165,63 +301,121 @@ func (p *parser) parseTime() (int, int, int, int, error) {
 			sec = parseInt(lit[4:6])
 			secParsed = true
 		default:
-			return parseErr(fmt.Errorf("expected time. got %s", lit))
+			return parseErr(p.unsupported([]string{"hh", "hhmm", "hhmmss"}, "expected time. got %s", lit))
+		}
+		p.fields.hour = p.last(0, 2)
+		p.details.Precision = PrecisionHour
+		if minParsed {
+			p.fields.min = p.last(2, 2)
+			p.details.Precision = PrecisionMinute
+		}
+		if secParsed {
+			p.fields.sec = p.last(4, 2)
+			p.details.Precision = PrecisionSecond
 		}
 	default:
-		return parseErr(fmt.Errorf("expected number. got %s", lit))
+		return parseErr(p.syntaxError([]string{"number"}, "expected number. got %s", lit))
 	}
 
 	// get the min
 	if !minParsed {
-		switch tok, lit := p.scan(); tok {
-		case EOF:
-			return hour, min, sec, nsec, nil
-		case COLON:
+		switch tok, lit := p.scan(); {
+		case tok == EOF:
+			return hour, min, sec, frac, nil
+		case tok == COLON:
 			min, err = p.scanNumber()
 			if err != nil {
 				return parseErr(err)
 			}
+			p.fields.min = p.last(0, -1)
+			p.details.Precision = PrecisionMinute
+		case isDecimalSign(tok):
+			// a fraction on the hour means there's no minute or second.
+			frac, err = p.scanFraction(time.Hour, "hours")
+			if err != nil {
+				return parseErr(err)
+			}
+			return hour, min, sec, frac, nil
 		default:
-			if beginsOffset(tok) {
+			if beginsOffset(tok) || tok == LBRACKET {
 				p.unscan()
-				return hour, min, sec, nsec, nil
+				return hour, min, sec, frac, nil
 			}
-			return parseErr(fmt.Errorf("expected colon, EOF, or timezone offset. got %s", lit))
+			return parseErr(p.syntaxError([]string{":", ".", ",", "EOF", "Z", "+", "-"}, "expected colon, EOF, or timezone offset. got %s", lit))
 		}
 	}
 
 	// get the sec
 	if !secParsed {
-		switch tok, _ := p.scan(); tok {
-		case EOF:
-			return hour, min, sec, nsec, nil
-		case COLON:
+		switch tok, _ := p.scan(); {
+		case tok == EOF:
+			return hour, min, sec, frac, nil
+		case tok == COLON:
 			sec, err = p.scanNumber()
 			if err != nil {
 				return parseErr(err)
 			}
+			p.fields.sec = p.last(0, -1)
+			p.details.Precision = PrecisionSecond
+		case isDecimalSign(tok):
+			// a fraction on the minute means there's no second.
+			frac, err = p.scanFraction(time.Minute, "minutes")
+			if err != nil {
+				return parseErr(err)
+			}
+			return hour, min, sec, frac, nil
+		default:
+			// anything else, like the Z or offset in T10:10Z, belongs to whatever comes after the time.
+			p.unscan()
 		}
 	}
 
 	// get the nsec
 	var lit string // make this one look slightly different to satisfy ineffassign check.
-	switch tok, _ := p.scan(); tok {
-	case EOF:
-		return hour, min, sec, nsec, nil
-	case DOT:
+	switch tok, _ := p.scan(); {
+	case tok == EOF:
+		return hour, min, sec, frac, nil
+	case isDecimalSign(tok):
 		// can't use scanNumber on the fractional part because we need to preserve leading zeros.
 		tok, lit = p.scan()
 
 		if tok != NUMBER {
-			return parseErr(fmt.Errorf("expected fraction of seconds.  got %s", lit))
+			return parseErr(p.syntaxError([]string{"number"}, "expected fraction of seconds.  got %s", lit))
 		}
 
-		secFrac := parseDecimal(lit)
-		nsec = int(round(secFrac * nsecsPerSec))
+		frac = time.Duration(p.fraction(lit, time.Second))
+		p.details.Precision = PrecisionSubsecond
+		p.details.FractionDigits = len(lit)
+		if len(lit) > 9 {
+			p.details.ExcessDigits = lit[9:]
+		}
 	default:
 		p.unscan()
 	}
-	return hour, min, sec, nsec, nil
+	return hour, min, sec, frac, nil
+}
+
+// scanFraction reads the digits after the decimal point on a fractional hour or minute, and returns
+// that fraction of the unit, rounded as the fraction method does.  It may round up to a whole unit.
+func (p *parser) scanFraction(unit time.Duration, name string) (time.Duration, error) {
+	tok, lit := p.scan()
+	if tok != NUMBER {
+		return 0, p.syntaxError([]string{"number"}, "expected fraction of %s.  got %s", name, lit)
+	}
+
+	p.details.FractionDigits = len(lit)
+	return time.Duration(p.fraction(lit, unit)), nil
+}
+
+// fraction returns the fraction of unit given by the digits after a decimal point, in nanoseconds.
+// It rounds to the nearest nanosecond, with halves rounded up, unless the TruncateFractions option
+// was given.
+func (p *parser) fraction(digits string, unit time.Duration) int64 {
+	if p.opts.truncateFractions {
+		return fractionOf(digits, int64(unit))
+	}
+	// get one more digit than we need, so we know which way to round.
+	return (fractionOf(digits, int64(unit)*10) + 5) / 10
 }
 
 func (p *parser) parseDate() (int, time.Month, int, error) {
@@ -235,56 +429,128 @@ func (p *parser) parseDate() (int, time.Month, int, error) {
 		return 0, time.Month(0), 0, err
 	}
 
-	unexpected := func(found, expected string) (int, time.Month, int, error) {
-		return parseErr(fmt.Errorf("found %s, expected %s", found, expected))
+	unexpected := func(expected ...string) (int, time.Month, int, error) {
+		return parseErr(p.unexpected(expected...))
 	}
 
-	// should start with a number like yyyy or yyyymmdd
-	switch tok, lit := p.scan(); tok {
-	case NUMBER:
-		switch len(lit) {
-		case 4:
-			year = parseInt(lit)
-		case 8:
-			// we should have yyyymmdd
-			year = parseInt(lit[:4])
-			monthNum := parseInt(lit[4:6])
-			month = time.Month(monthNum)
-			day = parseInt(lit[6:8])
-			return year, month, day, nil
-		default:
-			return unexpected(lit, "yyyy-mm-dd or yyyymmdd")
+	wrongLength := func(yearDigits int) (int, time.Month, int, error) {
+		if yearDigits > 4 {
+			expected := fmt.Sprintf("%d digit year", yearDigits)
+			return parseErr(p.unsupported([]string{expected}, "found %s, expected %s", p.buf.lit, expected))
+		}
+		expected := []string{"yyyy-mm-dd", "yyyymmdd", "yyyy-ddd", "yyyyddd"}
+		return parseErr(p.unsupported(expected, "found %s, expected %s", p.buf.lit, orList(expected)))
+	}
+
+	// should start with a number like yyyy or yyyymmdd.  If expanded years are allowed, there may be
+	// a sign and then extra year digits, like +yyyyyy.
+	tok, lit := p.scan()
+	sign, yearDigits := 1, 4
+	if p.opts.expandedYears > 0 && (tok == PLUS || tok == DASH) {
+		if tok == DASH {
+			sign = -1
 		}
+		yearDigits += p.opts.expandedYears
+		tok, lit = p.scan()
+	}
+	if tok != NUMBER {
+		return unexpected("number")
+	}
+	if len(lit) < yearDigits {
+		return wrongLength(yearDigits)
+	}
+	// expanded years can have more digits than an int holds, so they aren't safe for parseInt.
+	year, err = strconv.Atoi(lit[:yearDigits])
+	year *= sign
+	if err != nil || !checkYear(year) {
+		f := p.last(0, yearDigits)
+		if sign < 0 {
+			return parseErr(p.errorAt(RangeError, f, nil, "year -%s is outside the range time.Time supports", f.lit))
+		}
+		return parseErr(p.errorAt(RangeError, f, nil, "year %s is outside the range time.Time supports", f.lit))
+	}
+	p.details.Precision = PrecisionYear
+
+	switch rest := lit[yearDigits:]; len(rest) {
+	case 0:
+	case 3:
+		// we should have yyyyddd, an ordinal date
+		month, day, err = p.ordinalDate(year, p.last(yearDigits, 3))
+		if err != nil {
+			return parseErr(err)
+		}
+		p.details.Precision = PrecisionDay
+		return year, month, day, nil
+	case 4:
+		// we should have yyyymmdd
+		monthNum := parseInt(rest[:2])
+		month = time.Month(monthNum)
+		day = parseInt(rest[2:4])
+		p.fields.month = p.last(yearDigits, 2)
+		p.fields.day = p.last(yearDigits+2, 2)
+		p.details.Precision = PrecisionDay
+		return year, month, day, nil
 	default:
-		return unexpected(lit, "number")
+		return wrongLength(yearDigits)
 	}
 
-	// if we're here, then we've got a year but not yet a month or day.  Dash or "T" is next.
-	switch tok, lit := p.scan(); tok {
+	// if we're here, then we've got a year but not yet a month or day.  Dash, "W", or "T" is next.  A
+	// time after a year alone, like 2007T10, is on the first day of the year.
+	switch tok, _ := p.scan(); tok {
 	case T, EOF:
 		if tok == T {
 			p.unscan()
 		}
 		return year, month, day, nil
+	case W:
+		return p.parseWeekDate(year, false)
 	default:
 		if tok != DASH {
-			return unexpected(lit, "dash, T, or EOF")
+			return unexpected("dash", "W", "T", "EOF")
 		}
 	}
 
-	monthNum, err := p.scanNumber()
+	// a three digit number here is the day of the year in an ordinal date like yyyy-ddd, and a "W"
+	// starts a week date like yyyy-Www-d.  Any other number is a month.
+	tok, lit = p.scan()
+	if tok == W {
+		return p.parseWeekDate(year, true)
+	}
+	if tok != NUMBER {
+		return unexpected("number")
+	}
+	if len(lit) == 3 {
+		month, day, err = p.ordinalDate(year, p.last(0, -1))
+		if err != nil {
+			return parseErr(err)
+		}
+		p.details.Precision = PrecisionDay
+		switch tok, _ := p.scan(); tok {
+		case T, EOF:
+			if tok == T {
+				p.unscan()
+			}
+		default:
+			return unexpected("T", "EOF")
+		}
+		return year, month, day, nil
+	}
+	monthNum, err := strconv.Atoi(lit)
 	if err != nil {
-		return parseErr(err)
+		return parseErr(p.errorAt(RangeError, p.last(0, -1), nil, "%s is not a valid month", lit))
 	}
 	month = time.Month(monthNum)
+	p.fields.month = p.last(0, -1)
+	p.details.Precision = PrecisionMonth
 
-	// if we're here, then we've got a year and month but not yet a day.  Dash or "T" is next.
-	switch tok, lit := p.scan(); tok {
-	case T, EOF:
+	// if we're here, then we've got a year and month but not yet a day.  Dash is next, unless that's
+	// the end: a time can't follow a date without a day.
+	switch tok, _ := p.scan(); tok {
+	case EOF:
 		return year, month, day, nil
 	default:
 		if tok != DASH {
-			return unexpected(lit, "dash, T, or EOF")
+			return unexpected("dash", "EOF")
 		}
 	}
 
@@ -292,51 +558,214 @@ func (p *parser) parseDate() (int, time.Month, int, error) {
 	if err != nil {
 		return parseErr(err)
 	}
+	p.fields.day = p.last(0, -1)
+	p.details.Precision = PrecisionDay
 
-	switch tok, lit := p.scan(); tok {
+	switch tok, _ := p.scan(); tok {
 	case T, EOF:
 		if tok == T {
 			p.unscan()
 		}
 	default:
-		return unexpected(lit, "T or EOF")
+		return unexpected("T", "EOF")
 	}
 
 	return year, month, day, nil
 }
 
+// ordinalDate takes a year and the field with a three digit day of the year, and returns the month
+// and day of the month that it falls on.
+func (p *parser) ordinalDate(year int, f field) (time.Month, int, error) {
+	yday := parseInt(f.lit)
+	if !checkYearDay(year, yday) {
+		return time.Month(0), 0, p.errorAt(RangeError, f, nil, "%s is not a valid day of the year in %d", f.lit, year)
+	}
+	t := time.Date(year, time.January, yday, 0, 0, 0, 0, time.UTC)
+	return t.Month(), t.Day(), nil
+}
+
+// parseWeekDate parses the week and optional weekday of a week date, after the "W" has already been
+// read.  In the basic format those are written as Www or Wwwd, and in the extended format as Www or
+// Www-d.  It returns the calendar year, month, and day that the week date falls on.
+func (p *parser) parseWeekDate(year int, extended bool) (int, time.Month, int, error) {
+	week, weekday := 0, 1
+	var weekField, weekdayField field
+	parseErr := func(err error) (int, time.Month, int, error) {
+		return 0, time.Month(0), 0, err
+	}
+
+	tok, lit := p.scan()
+	if tok != NUMBER {
+		return parseErr(p.unexpected("week number"))
+	}
+	switch {
+	case len(lit) == 2:
+		week = parseInt(lit)
+		weekField = p.last(0, 2)
+	case len(lit) == 3 && !extended:
+		week = parseInt(lit[:2])
+		weekday = parseInt(lit[2:])
+		weekField, weekdayField = p.last(0, 2), p.last(2, 1)
+	default:
+		if extended {
+			return parseErr(p.unsupported([]string{"ww"}, "found %s, expected ww", lit))
+		}
+		return parseErr(p.unsupported([]string{"ww", "wwd"}, "found %s, expected ww or wwd", lit))
+	}
+
+	tok, lit = p.scan()
+	if tok == DASH && extended {
+		tok, lit = p.scan()
+		if tok != NUMBER || len(lit) != 1 {
+			return parseErr(p.unexpected("day of the week"))
+		}
+		weekday = parseInt(lit)
+		weekdayField = p.last(0, 1)
+		tok, _ = p.scan()
+	}
+	switch tok {
+	case T, EOF:
+		if tok == T {
+			p.unscan()
+		}
+	default:
+		return parseErr(p.unexpected("T", "EOF"))
+	}
+
+	if !checkWeek(year, week) {
+		return parseErr(p.errorAt(RangeError, weekField, nil, "%02d is not a valid week in %d", week, year))
+	}
+	if weekday < 1 || weekday > 7 {
+		return parseErr(p.errorAt(RangeError, weekdayField, nil, "%d is not a valid day of the week", weekday))
+	}
+	p.details.Precision = PrecisionWeek
+	if weekdayField.lit != "" {
+		p.details.Precision = PrecisionDay
+	}
+	y, m, d := weekDate(year, week, weekday)
+	return y, m, d, nil
+}
+
 func (p *parser) scanNumber() (int, error) {
-	if tok, lit := p.scan(); tok == NUMBER {
-		return strconv.Atoi(lit)
-	} else {
-		return 0, fmt.Errorf("found %s, expected number", lit)
+	tok, lit := p.scan()
+	if tok != NUMBER {
+		return 0, p.unexpected("number")
 	}
+	n, err := strconv.Atoi(lit)
+	if err != nil {
+		return 0, p.errorAt(RangeError, p.last(0, -1), nil, "%s is out of range", lit)
+	}
+	return n, nil
 }
 
-func buildTime(year int, month time.Month, day, hour, min, sec, nsec int, loc *time.Location) (time.Time, error) {
-	// year has already been checked for 4 digit length.
+// buildTime checks the fields and returns the time they give, with the fraction of the last time
+// field added.  Adding the fraction as a duration lets one that rounded up carry into the next unit.
+func (p *parser) buildTime(year int, month time.Month, day, hour, min, sec int, frac time.Duration, loc *time.Location) (time.Time, error) {
+	if err := p.checkDate(year, month, day); err != nil {
+		return zeroTime, err
+	}
+
+	if err := p.checkTime(hour, min, sec, frac); err != nil {
+		return zeroTime, err
+	}
+
+	// anything without a Z or offset got the default location.
+	if p.details.Zone == 0 {
+		p.details.Zone = ZoneDefault
+	}
+
+	if err := p.checkPrecision(); err != nil {
+		return zeroTime, err
+	}
+
+	return time.Date(year, month, day, hour, min, sec, 0, loc).Add(frac), nil
+}
+
+// checkTime returns an error if the hour, minute, or second is out of range.
+func (p *parser) checkTime(hour, min, sec int, frac time.Duration) error {
+	// 24:00:00 is the end of the day, which time.Date will normalize to midnight of the next day.
+	endOfDay := hour == 24 && min == 0 && sec == 0 && frac == 0
+	if !checkHour(hour) && !endOfDay {
+		return p.errorAt(RangeError, p.fields.hour, nil, "%02d is not a valid hour", hour)
+	}
+
+	if !checkMinSec(min) {
+		return p.errorAt(RangeError, p.fields.min, nil, "%02d is not a valid minute", min)
+	}
+
+	// a leap second can only be added at the end of a minute.  time.Date will normalize it to the
+	// start of the next minute.
+	leapSecond := sec == 60 && min == 59 && !p.opts.noLeapSeconds
+	if !checkMinSec(sec) && !leapSecond {
+		return p.errorAt(RangeError, p.fields.sec, nil, "%02d is not a valid second", sec)
+	}
+	p.details.LeapSecond = leapSecond
+	return nil
+}
 
+// checkDate returns an error if the month or day is out of range.  The year has already been checked
+// by parseDate.
+func (p *parser) checkDate(year int, month time.Month, day int) error {
 	if !checkMonth(int(month)) {
-		return zeroTime, fmt.Errorf("%02d is not a valid month", month)
+		return p.errorAt(RangeError, p.fields.month, nil, "%02d is not a valid month", month)
 	}
 
 	if !checkDay(month, day) {
-		return zeroTime, fmt.Errorf("%02d is not a valid day in %s", day, month)
+		return p.errorAt(RangeError, p.fields.day, nil, "%02d is not a valid day in %s", day, month)
 	}
 
-	if !checkHour(hour) {
-		return zeroTime, fmt.Errorf("%02d is not a valid hour", hour)
+	if !p.opts.lenient && !checkYearMonthDay(year, month, day) {
+		return p.errorAt(RangeError, p.fields.day, nil, "%02d is not a valid day in %s %d", day, month, year)
 	}
+	return nil
+}
 
-	if !checkMinSec(min) {
-		return zeroTime, fmt.Errorf("%02d is not a valid minute", min)
+// checkPrecision returns an error if the timestamp's precision isn't one of the allowed ones.
+func (p *parser) checkPrecision() error {
+	if !p.opts.precision(p.details.Precision) {
+		return p.errorAt(UnsupportedError, field{offset: len(p.input)}, nil, "%s precision is not allowed", p.details.Precision)
 	}
+	return nil
+}
+
+// weekDate takes an ISO 8601 week-numbering year, week, and day of the week (1 for Monday through 7
+// for Sunday), and returns the calendar year, month, and day that it falls on.  Early or late weeks
+// may fall in the calendar year before or after the week-numbering year.
+// The week and weekday must already have been checked.
+func weekDate(year, week, weekday int) (int, time.Month, int) {
+	// January 4th is always in week 1, so count from the Monday on or before it.
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	t := monday.AddDate(0, 0, (week-1)*7+weekday-1)
+	return t.Year(), t.Month(), t.Day()
+}
+
+// checkWeek returns whether the given week is valid in the given ISO 8601 week-numbering year.  Most
+// years have 52 weeks, but those that start on a Thursday (or leap years that start on a Wednesday)
+// have 53.
+func checkWeek(year, week int) bool {
+	// December 28th is always in the last week of its week-numbering year.
+	_, max := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week > 0 && week <= max
+}
+
+// checkYear returns whether the given year is in the range that time.Time can represent.
+func checkYear(year int) bool {
+	return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC).Year() == year
+}
 
-	if !checkMinSec(sec) {
-		return zeroTime, fmt.Errorf("%02d is not a valid second", sec)
+// checkYearDay returns whether the given day of the year is valid in the given year.
+func checkYearDay(year, yday int) bool {
+	max := 365
+	if isLeap(year) {
+		max = 366
 	}
+	return yday > 0 && yday <= max
+}
 
-	return time.Date(year, month, day, hour, min, sec, nsec, loc), nil
+// isLeap returns whether the given year is a leap year in the Gregorian calendar.
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
 }
 
 func checkMinSec(val int) bool {
@@ -354,22 +783,34 @@ func checkMonth(val int) bool {
 // checkDay returns whether the given day is valid in the given month.  Note that it is not aware of
 // leap years, so will allow Feb 29th every year.
 func checkDay(month time.Month, day int) bool {
-	monthDays := map[time.Month]int{
-		time.January:   31,
-		time.February:  29,
-		time.March:     31,
-		time.April:     30,
-		time.May:       31,
-		time.June:      30,
-		time.July:      31,
-		time.August:    31,
-		time.September: 30,
-		time.October:   31,
-		time.November:  30,
-		time.December:  31,
-	}
-	max, ok := monthDays[month]
-	return ok && day > 0 && day <= max
+	return day > 0 && day <= daysIn(month, 2000)
+}
+
+// checkYearMonthDay returns whether the given day is valid in the given month and year, following the
+// Gregorian calendar's leap year rules.
+func checkYearMonthDay(year int, month time.Month, day int) bool {
+	return day > 0 && day <= daysIn(month, year)
+}
+
+// daysIn returns the number of days in the given month of the given year.
+func daysIn(month time.Month, year int) int {
+	switch month {
+	case time.February:
+		if isLeap(year) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	default:
+		return 31
+	}
+}
+
+// isDecimalSign tells you whether the token separates a whole number from its fraction.  ISO 8601
+// allows both a comma and a dot, and prefers the comma.
+func isDecimalSign(tok token) bool {
+	return tok == DOT || tok == COMMA
 }
 
 // beginsOffset tells you whether the token is a valid first token for a timezone offset.
@@ -387,9 +828,15 @@ func parseInt(in string) int {
 	return out
 }
 
-// parseDecimal takes in a string like "0234" and returns it as the decimal portion of a float, like 0.0234.
-// It assumes that the in string has already been validated as having only digits (so will not error
-// on strconv.Atoi), and will panic if that assumption is violated.
-func parseDecimal(in string) float64 {
-	return float64(parseInt(in)) / math.Pow(10.0, float64(len(in)))
+// fractionOf takes in a string of digits from after a decimal point, like "5" for 0.5, and returns
+// that fraction of unit, truncated to a whole number.  It uses only integer arithmetic, so it's exact
+// for any number of digits.  It assumes that the digits string has already been validated.
+func fractionOf(digits string, unit int64) int64 {
+	// Horner's method, working from the least significant digit.  Truncating at each step gives the
+	// same result as truncating once at the end.
+	var out int64
+	for i := len(digits) - 1; i >= 0; i-- {
+		out = (int64(digits[i]-'0')*unit + out) / 10
+	}
+	return out
 }
@@ -0,0 +1,42 @@
+package datetime
+
+import "fmt"
+
+// Precision is how much of a timestamp was given, from just a year down to a fraction of a second.
+type Precision int
+
+// Precisions from least to most precise.
+const (
+	PrecisionYear Precision = iota + 1
+	PrecisionMonth
+	PrecisionWeek
+	PrecisionDay
+	PrecisionHour
+	PrecisionMinute
+	PrecisionSecond
+	PrecisionSubsecond
+)
+
+// String returns the precision's name.
+func (p Precision) String() string {
+	switch p {
+	case PrecisionYear:
+		return "year"
+	case PrecisionMonth:
+		return "month"
+	case PrecisionWeek:
+		return "week"
+	case PrecisionDay:
+		return "day"
+	case PrecisionHour:
+		return "hour"
+	case PrecisionMinute:
+		return "minute"
+	case PrecisionSecond:
+		return "second"
+	case PrecisionSubsecond:
+		return "subsecond"
+	default:
+		return fmt.Sprintf("Precision(%d)", int(p))
+	}
+}
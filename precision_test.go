@@ -0,0 +1,28 @@
+package datetime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrecisionString(t *testing.T) {
+	tt := []struct {
+		precision Precision
+		output    string
+	}{
+		{PrecisionYear, "year"},
+		{PrecisionMonth, "month"},
+		{PrecisionWeek, "week"},
+		{PrecisionDay, "day"},
+		{PrecisionHour, "hour"},
+		{PrecisionMinute, "minute"},
+		{PrecisionSecond, "second"},
+		{PrecisionSubsecond, "subsecond"},
+		{Precision(0), "Precision(0)"},
+	}
+
+	for _, tc := range tt {
+		assert.Equal(t, tc.output, tc.precision.String())
+	}
+}
@@ -0,0 +1,252 @@
+package datetime
+
+import (
+	"database/sql/driver"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence is an ISO 8601 recurring time interval, like R5/2008-03-01T13:00:00Z/P1D.  Each
+// occurrence is an Interval starting one duration after the one before it.
+//
+// Occurrences are only found while the duration's hours, minutes, and seconds, multiplied by the
+// occurrence's index, fit in a time.Duration, so a recurrence like R/2008-03-01T13:00:00Z/PT1S has
+// none more than about 292 years after its start.
+type Recurrence struct {
+	// repetitions is the number of occurrences, or -1 if there's no limit.
+	repetitions int
+	start       time.Time
+	duration    Duration
+}
+
+// ParseRecurrence takes a string with an ISO 8601 recurring time interval in it, and a default
+// location to use for timestamps that don't include one, and returns a Recurrence.  The string is an
+// R, an optional number of occurrences, a slash, and then an interval in start/duration or start/end
+// form, as accepted by ParseInterval.  Without a number, the recurrence never ends.
+func ParseRecurrence(s string, defaultLocation *time.Location) (Recurrence, error) {
+	parts := splitSlashes(s, 2)
+	switch {
+	case !strings.HasPrefix(s, "R"):
+		return Recurrence{}, inputError(s, 0, parts[0], []string{"R"}, SyntaxError,
+			"%s is not an Rn/interval recurrence", s)
+	case len(parts) != 2:
+		return Recurrence{}, inputError(s, len(s), "", []string{"/"}, SyntaxError, "%s is not an Rn/interval recurrence", s)
+	}
+
+	repetitions := -1
+	if count := parts[0][1:]; count != "" {
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return Recurrence{}, inputError(s, 1, count, []string{"number"}, SyntaxError, "%s is not a valid number of repetitions", count)
+		}
+		if n < 0 {
+			return Recurrence{}, inputError(s, 1, count, nil, RangeError, "%s is not a valid number of repetitions", count)
+		}
+		repetitions = n
+	}
+
+	intervalOffset := len(parts[0]) + 1
+	interval, err := ParseInterval(parts[1], defaultLocation)
+	if err != nil {
+		return Recurrence{}, withinInput(err, s, intervalOffset)
+	}
+
+	ends := splitSlashes(parts[1], 2)
+	if isDuration(ends[0]) {
+		return Recurrence{}, inputError(s, intervalOffset, ends[0], []string{"timestamp"}, SyntaxError, "recurrence must have a start")
+	}
+	duration := Duration{Clock: interval.Duration()}
+	if isDuration(ends[1]) {
+		// parse it again to keep the calendar components.  ParseInterval has already checked it.
+		duration, _ = ParseDuration(ends[1])
+	}
+	if duration.approximate() <= 0 {
+		return Recurrence{}, inputError(s, intervalOffset+len(ends[0])+1, ends[1], nil, RangeError, "recurrence duration must be positive")
+	}
+
+	return Recurrence{repetitions: repetitions, start: interval.Start(), duration: duration}, nil
+}
+
+// Start returns the beginning of the first occurrence.
+func (r Recurrence) Start() time.Time { return r.start }
+
+// Duration returns the length of each occurrence, which is also the time between their starts.
+func (r Recurrence) Duration() Duration { return r.duration }
+
+// Repetitions returns the number of occurrences, or -1 if the recurrence never ends.
+func (r Recurrence) Repetitions() int { return r.repetitions }
+
+// occurrence returns the start of the nth occurrence, counting from zero.  Calendar components are
+// multiplied rather than added repeatedly, so that P1M starting on January 31st doesn't drift to the
+// 28th of each month after February.
+func (r Recurrence) occurrence(n int) time.Time {
+	return r.duration.times(n).AddTo(r.start)
+}
+
+// interval returns the nth occurrence, counting from zero.  It ends where the next one would start,
+// so that occurrences after a clamped month end, like February 28th in a P1M recurrence from January
+// 31st, don't leave a gap before the next one.
+func (r Recurrence) interval(n int) Interval {
+	return Interval{start: r.occurrence(n), end: r.occurrence(n + 1)}
+}
+
+// maxRecurrenceYears is how far from the start a recurrence's occurrences can be computed, well
+// within the years a time.Time can represent.
+const maxRecurrenceYears = 1e9
+
+// lastIndex returns the index of the last occurrence, or -1 if there are none.  A recurrence that
+// never ends stops at the last occurrence that can be computed: one whose clock part still fits in a
+// time.Duration, and whose calendar part is within maxRecurrenceYears.
+func (r Recurrence) lastIndex() int {
+	last := int(^uint(0) >> 1)
+	if clock := r.duration.Clock; clock > 0 && math.MaxInt64/clock < time.Duration(last) {
+		// leave room for the end of the last occurrence, which is where the one after it would start.
+		last = int(math.MaxInt64/clock) - 1
+	}
+	d := r.duration
+	if years := float64(d.Years) + float64(d.Months)/12 + float64(d.Weeks*7+d.Days)/365; years > 0 &&
+		maxRecurrenceYears/years < float64(last) {
+		last = int(maxRecurrenceYears / years)
+	}
+	if r.repetitions >= 0 && r.repetitions-1 < last {
+		last = r.repetitions - 1
+	}
+	return last
+}
+
+// inRange returns whether there is an nth occurrence.
+func (r Recurrence) inRange(n int) bool {
+	return n >= 0 && n <= r.lastIndex()
+}
+
+// firstIndex returns the index of the first occurrence starting after t, or at t if inclusive is set.
+// If there isn't one, it returns one more than lastIndex.
+func (r Recurrence) firstIndex(t time.Time, inclusive bool) int {
+	matches := func(n int) bool {
+		o := r.occurrence(n)
+		return o.After(t) || (inclusive && o.Equal(t))
+	}
+
+	// occurrences are in order, so a binary search finds the first match without computing more
+	// than a few dozen of them, however far t is from the start.
+	lo, hi := 0, r.lastIndex()+1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if matches(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// Next returns the first occurrence starting after the given time.  It returns false if there isn't
+// one.
+func (r Recurrence) Next(after time.Time) (Interval, bool) {
+	n := r.firstIndex(after, false)
+	if !r.inRange(n) {
+		return Interval{}, false
+	}
+	return r.interval(n), true
+}
+
+// Occurrences returns every occurrence starting at or after from and before to.
+func (r Recurrence) Occurrences(from, to time.Time) []Interval {
+	var out []Interval
+	for n := r.firstIndex(from, true); r.inRange(n); n++ {
+		i := r.interval(n)
+		if !i.start.Before(to) {
+			break
+		}
+		out = append(out, i)
+	}
+	return out
+}
+
+// IsZero tells you whether r is the zero value, which has no occurrences.
+func (r Recurrence) IsZero() bool {
+	return r == Recurrence{}
+}
+
+// String returns the Recurrence's ISO 8601 representation, with an RFC3339Nano start and a duration.
+func (r Recurrence) String() string {
+	count := ""
+	if r.repetitions >= 0 {
+		count = strconv.Itoa(r.repetitions)
+	}
+	return "R" + count + "/" + formatNano(r.start) + "/" + r.duration.String()
+}
+
+// MarshalText implements the encoding TextMarshaler interface.  The zero value is written as an
+// empty string.
+func (r Recurrence) MarshalText() ([]byte, error) {
+	if r.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding TextUnmarshaler interface.  Like DefaultUTC, it uses time.UTC
+// as the location for timestamps that don't specify one.  An empty string is read as the zero value.
+func (r *Recurrence) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*r = Recurrence{}
+		return nil
+	}
+	parsed, err := ParseRecurrence(string(data), time.UTC)
+	*r = parsed
+	return err
+}
+
+// MarshalJSON implements the JSON Marshaler interface, writing the recurrence as a JSON string.  The
+// zero value is written as null, to match UnmarshalJSON.
+func (r Recurrence) MarshalJSON() ([]byte, error) {
+	if r.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + r.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the JSON Unmarshaler interface, allowing datetime.Recurrence struct
+// fields to be read from JSON string fields.
+func (r *Recurrence) UnmarshalJSON(data []byte) error {
+	// Ignore null, like in the main JSON package.
+	if string(data) == "null" {
+		*r = Recurrence{}
+		return nil
+	}
+	trimmed, err := trimQuotes(data)
+	if err != nil {
+		*r = Recurrence{}
+		return err
+	}
+	// report the error's position in the quoted JSON, not the string inside it.
+	return withinInput(r.UnmarshalText(trimmed), string(data), 1)
+}
+
+// Scan implements the sql Scanner interface, allowing datetime.Recurrence fields to be read from
+// database columns.  NULL is read as the zero value.
+func (r *Recurrence) Scan(value interface{}) error {
+	if value == nil {
+		*r = Recurrence{}
+		return nil
+	}
+	b, err := scanBytes(value)
+	if err != nil {
+		*r = Recurrence{}
+		return err
+	}
+	return r.UnmarshalText(b)
+}
+
+// Value implements the sql Valuer interface, allowing datetime.Recurrence fields to be saved to
+// database columns.  The zero value is saved as NULL.
+func (r Recurrence) Value() (driver.Value, error) {
+	if r.IsZero() {
+		return nil, nil
+	}
+	return r.String(), nil
+}
@@ -0,0 +1,262 @@
+package datetime
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+	tt := []struct {
+		input       string
+		repetitions int
+		start       time.Time
+		duration    Duration
+	}{
+		{
+			input:       "R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M",
+			repetitions: 5,
+			start:       time.Date(2008, time.March, 1, 13, 0, 0, 0, time.UTC),
+			duration:    Duration{Years: 1, Months: 2, Days: 10, Clock: 150 * time.Minute},
+		},
+		{
+			input:       "R/2008-03-01T13:00:00Z/P1D",
+			repetitions: -1,
+			start:       time.Date(2008, time.March, 1, 13, 0, 0, 0, time.UTC),
+			duration:    Duration{Days: 1},
+		},
+		{
+			input:       "R0/2008-03-01/2008-03-02",
+			repetitions: 0,
+			start:       time.Date(2008, time.March, 1, 0, 0, 0, 0, time.UTC),
+			duration:    Duration{Clock: 24 * time.Hour},
+		},
+		{
+			input:       "R3/2007-03-01T13:00:00-05:00[America/New_York]/P1D",
+			repetitions: 3,
+			start:       time.Date(2007, time.March, 1, 13, 0, 0, 0, ny),
+			duration:    Duration{Days: 1},
+		},
+		{
+			input:       "R/2007-03-01T13:00:00-05:00[America/New_York]/2007-03-01T15:00:00-05:00[America/New_York]",
+			repetitions: -1,
+			start:       time.Date(2007, time.March, 1, 13, 0, 0, 0, ny),
+			duration:    Duration{Clock: 2 * time.Hour},
+		},
+	}
+
+	for _, tc := range tt {
+		r, err := ParseRecurrence(tc.input, time.UTC)
+		assert.Nil(t, err, tc.input)
+		assert.Equal(t, tc.repetitions, r.Repetitions(), tc.input)
+		assert.Equal(t, tc.start, r.Start(), tc.input)
+		assert.Equal(t, tc.duration, r.Duration(), tc.input)
+	}
+}
+
+func TestParseRecurrenceErrors(t *testing.T) {
+	tt := []struct {
+		input string
+		err   string
+	}{
+		{
+			input: "2008-03-01/P1D",
+			err:   "2008-03-01/P1D is not an Rn/interval recurrence",
+		},
+		{
+			input: "R5",
+			err:   "R5 is not an Rn/interval recurrence",
+		},
+		{
+			input: "Rx/2008-03-01/P1D",
+			err:   "x is not a valid number of repetitions",
+		},
+		{
+			input: "R-1/2008-03-01/P1D",
+			err:   "-1 is not a valid number of repetitions",
+		},
+		{
+			input: "R/P1D/2008-03-01",
+			err:   "recurrence must have a start",
+		},
+		{
+			input: "R/2008-03-01/PT0S",
+			err:   "recurrence duration must be positive",
+		},
+		{
+			input: "R/2008-03-01/-P1D",
+			err:   "interval ends before it starts",
+		},
+		{
+			input: "R/2008-13/P1D",
+			err:   "13 is not a valid month",
+		},
+	}
+
+	for _, tc := range tt {
+		r, err := ParseRecurrence(tc.input, time.UTC)
+		assert.Equal(t, Recurrence{}, r, tc.input)
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	r, err := ParseRecurrence("R3/2008-01-31T13:00:00Z/P1M", time.UTC)
+	assert.Nil(t, err)
+
+	i, ok := r.Next(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2008, time.January, 31, 13, 0, 0, 0, time.UTC), i.Start())
+	assert.Equal(t, time.Date(2008, time.February, 29, 13, 0, 0, 0, time.UTC), i.End())
+
+	i, ok = r.Next(time.Date(2008, time.January, 31, 13, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2008, time.February, 29, 13, 0, 0, 0, time.UTC), i.Start())
+
+	// the third occurrence is two months after the start, not one month after the second.
+	i, ok = r.Next(time.Date(2008, time.February, 29, 13, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2008, time.March, 31, 13, 0, 0, 0, time.UTC), i.Start())
+
+	_, ok = r.Next(time.Date(2008, time.March, 31, 13, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+
+	// month-end schedules get one occurrence in every month, on its last day when it's short.
+	monthEnd, err := ParseRecurrence("R6/2007-01-31T00:00:00Z/P1M", time.UTC)
+	assert.Nil(t, err)
+	var starts []time.Time
+	occurrences := monthEnd.Occurrences(zeroTime, time.Date(2008, time.January, 1, 0, 0, 0, 0, time.UTC))
+	for i, o := range occurrences {
+		starts = append(starts, o.Start())
+		if i > 0 {
+			assert.Equal(t, occurrences[i-1].End(), o.Start())
+		}
+	}
+	assert.Equal(t, []time.Time{
+		time.Date(2007, time.January, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2007, time.February, 28, 0, 0, 0, 0, time.UTC),
+		time.Date(2007, time.March, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2007, time.April, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2007, time.May, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2007, time.June, 30, 0, 0, 0, 0, time.UTC),
+	}, starts)
+
+	unbounded, err := ParseRecurrence("R/2008-03-01T13:00:00Z/P1D", time.UTC)
+	assert.Nil(t, err)
+	i, ok = unbounded.Next(time.Date(2100, time.June, 15, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2100, time.June, 15, 13, 0, 0, 0, time.UTC), i.Start())
+
+	// times far from the start don't overflow or take long to reach.
+	seconds, err := ParseRecurrence("R/2008-03-01T13:00:00Z/PT1S", time.UTC)
+	assert.Nil(t, err)
+	i, ok = seconds.Next(time.Date(2200, time.June, 15, 0, 0, 0, 500, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2200, time.June, 15, 0, 0, 1, 0, time.UTC), i.Start())
+	_, ok = seconds.Next(time.Date(2500, time.June, 15, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+
+	yearly, err := ParseRecurrence("R/2008-03-01T13:00:00Z/P1Y", time.UTC)
+	assert.Nil(t, err)
+	i, ok = yearly.Next(time.Date(9000, time.June, 15, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(9001, time.March, 1, 13, 0, 0, 0, time.UTC), i.Start())
+
+	many, err := ParseRecurrence("R9999999999999/2008-03-01T13:00:00Z/PT1S", time.UTC)
+	assert.Nil(t, err)
+	_, ok = many.Next(time.Date(2500, time.June, 15, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	r, err := ParseRecurrence("R/2008-03-01T13:00:00Z/P1D", time.UTC)
+	assert.Nil(t, err)
+
+	occurrences := r.Occurrences(
+		time.Date(2008, time.March, 3, 13, 0, 0, 0, time.UTC),
+		time.Date(2008, time.March, 6, 0, 0, 0, 0, time.UTC),
+	)
+	assert.Equal(t, []Interval{
+		NewInterval(time.Date(2008, time.March, 3, 13, 0, 0, 0, time.UTC), time.Date(2008, time.March, 4, 13, 0, 0, 0, time.UTC)),
+		NewInterval(time.Date(2008, time.March, 4, 13, 0, 0, 0, time.UTC), time.Date(2008, time.March, 5, 13, 0, 0, 0, time.UTC)),
+		NewInterval(time.Date(2008, time.March, 5, 13, 0, 0, 0, time.UTC), time.Date(2008, time.March, 6, 13, 0, 0, 0, time.UTC)),
+	}, occurrences)
+
+	bounded, err := ParseRecurrence("R2/2008-03-01T13:00:00Z/P1D", time.UTC)
+	assert.Nil(t, err)
+	assert.Len(t, bounded.Occurrences(zeroTime, time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)), 2)
+	assert.Len(t, Recurrence{}.Occurrences(zeroTime, time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)), 0)
+}
+
+func TestRecurrenceJSON(t *testing.T) {
+	type wrapper struct {
+		R Recurrence `json:"r"`
+	}
+
+	r, err := ParseRecurrence("R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M", time.UTC)
+	assert.Nil(t, err)
+
+	out, err := json.Marshal(wrapper{R: r})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"r":"R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M"}`, string(out))
+
+	var w wrapper
+	assert.Nil(t, json.Unmarshal(out, &w))
+	assert.Equal(t, r, w.R)
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"r":null}`), &w))
+	assert.Equal(t, Recurrence{}, w.R)
+
+	// the zero value round trips.
+	out, err = json.Marshal(wrapper{})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"r":null}`, string(out))
+	w = wrapper{R: r}
+	assert.Nil(t, json.Unmarshal(out, &w))
+	assert.Equal(t, Recurrence{}, w.R)
+
+	text, err := Recurrence{}.MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, "", string(text))
+	assert.Nil(t, r.UnmarshalText(text))
+	assert.Equal(t, Recurrence{}, r)
+
+	// offset seconds are kept, so the start reads back the same.
+	r, err = ParseRecurrence("R2/1937-06-30T10:00:00+00:19:32/P1D", time.UTC)
+	assert.Nil(t, err)
+	assert.Equal(t, "R2/1937-06-30T10:00:00+00:19:32/P1D", r.String())
+	out, err = json.Marshal(wrapper{R: r})
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(out, &w))
+	assert.True(t, r.Start().Equal(w.R.Start()))
+
+	err = json.Unmarshal([]byte(`{"r":1}`), &w)
+	assert.EqualError(t, err, "1 does not begin and end with double quotes")
+}
+
+func TestRecurrenceScanValue(t *testing.T) {
+	var r Recurrence
+	assert.Nil(t, r.Scan([]byte("R/2008-03-01T13:00:00Z/P1D")))
+	assert.Equal(t, -1, r.Repetitions())
+
+	val, err := r.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "R/2008-03-01T13:00:00Z/P1D", val)
+
+	assert.Nil(t, r.Scan("R2/2008-03-01/P1W"))
+	assert.Equal(t, Duration{Weeks: 1}, r.Duration())
+
+	assert.EqualError(t, r.Scan(1), "can only scan string and []byte, not int")
+	assert.Equal(t, Recurrence{}, r)
+
+	val, err = r.Value()
+	assert.Nil(t, err)
+	assert.Nil(t, val)
+	assert.Nil(t, r.Scan("R2/2008-03-01/P1W"))
+	assert.Nil(t, r.Scan(nil))
+	assert.Equal(t, Recurrence{}, r)
+}
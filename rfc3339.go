@@ -0,0 +1,205 @@
+package datetime
+
+import (
+	"database/sql/driver"
+	"time"
+)
+
+// RFC3339 makes the Parser accept only the RFC 3339 profile of ISO 8601: a full date and a full time
+// with seconds, in the extended format, with a Z or ±hh:mm offset.  As RFC 3339 allows, the date and
+// time may be separated by 't' or a space as well as 'T', and 'z' may be used for 'Z'.  Basic format,
+// ordinal and week dates, reduced precision, comma decimals, and 24:00 are all rejected.
+//
+// The DefaultLocation, Lenient, RejectLeapSeconds, TruncateFractions, and MaxOffset options still
+// apply, and the other options are ignored.
+func RFC3339() Option {
+	return func(o *options) { o.profile = profileRFC3339 }
+}
+
+var rfc3339Parser = NewParser(RFC3339())
+
+// ParseRFC3339 takes a string with a strict RFC 3339 timestamp in it and returns a time.Time.  See
+// the RFC3339 option for what's accepted.
+func ParseRFC3339(s string) (time.Time, error) {
+	return rfc3339Parser.Parse(s)
+}
+
+// parseRFC3339 parses a timestamp that must follow RFC 3339's date-time grammar exactly.
+func (p *parser) parseRFC3339() (time.Time, error) {
+	year, err := p.scanDigits(4, "yyyy")
+	if err != nil {
+		return zeroTime, err
+	}
+	if err := p.expect(DASH, "-"); err != nil {
+		return zeroTime, err
+	}
+	month, err := p.scanDigits(2, "mm")
+	if err != nil {
+		return zeroTime, err
+	}
+	p.fields.month = p.last(0, -1)
+	if err := p.expect(DASH, "-"); err != nil {
+		return zeroTime, err
+	}
+	day, err := p.scanDigits(2, "dd")
+	if err != nil {
+		return zeroTime, err
+	}
+	p.fields.day = p.last(0, -1)
+
+	// the scanner gives 'T', 't', and ' ' all as T.
+	if err := p.expect(T, "T", "t", "space"); err != nil {
+		return zeroTime, err
+	}
+
+	hour, err := p.scanDigits(2, "hh")
+	if err != nil {
+		return zeroTime, err
+	}
+	p.fields.hour = p.last(0, -1)
+	if hour == 24 {
+		return zeroTime, p.errorAt(RangeError, p.fields.hour, nil, "%02d is not a valid hour", hour)
+	}
+	if err := p.expect(COLON, ":"); err != nil {
+		return zeroTime, err
+	}
+	min, err := p.scanDigits(2, "mm")
+	if err != nil {
+		return zeroTime, err
+	}
+	p.fields.min = p.last(0, -1)
+	if err := p.expect(COLON, ":"); err != nil {
+		return zeroTime, err
+	}
+	sec, err := p.scanDigits(2, "ss")
+	if err != nil {
+		return zeroTime, err
+	}
+	p.fields.sec = p.last(0, -1)
+	p.details.Precision = PrecisionSecond
+
+	var nsec int
+	tok, lit := p.scan()
+	if tok == DOT {
+		if tok, lit = p.scan(); tok != NUMBER {
+			return zeroTime, p.unexpected("fraction of seconds")
+		}
+		nsec = int(p.fraction(lit, time.Second))
+		p.details.Precision = PrecisionSubsecond
+		p.details.FractionDigits = len(lit)
+		if len(lit) > 9 {
+			p.details.ExcessDigits = lit[9:]
+		}
+		tok, lit = p.scan()
+	}
+
+	var loc *time.Location
+	switch tok {
+	case Z:
+		loc = time.UTC
+		p.details.Zone = ZoneUTC
+		p.fields.zone = p.last(0, -1)
+	case PLUS, DASH:
+		if loc, err = p.scanExtendedOffset(lit, p.opts.maxOffset); err != nil {
+			return zeroTime, err
+		}
+	default:
+		return zeroTime, p.unexpected("Z", "timezone offset")
+	}
+
+	if tok, _ := p.scan(); tok != EOF {
+		return zeroTime, p.unexpected("EOF")
+	}
+
+	return p.buildTime(year, time.Month(month), day, hour, min, sec, time.Duration(nsec), loc)
+}
+
+// scanDigits reads a number that must have exactly n digits, and returns its value.
+func (p *parser) scanDigits(n int, name string) (int, error) {
+	tok, lit := p.scan()
+	if tok != NUMBER || len(lit) != n {
+		return 0, p.unexpected(name)
+	}
+	return parseInt(lit), nil
+}
+
+// scanExtendedOffset reads a ±hh:mm timezone offset after its sign has been read, and checks that
+// it's no more than max from UTC.
+func (p *parser) scanExtendedOffset(sign string, max time.Duration) (*time.Location, error) {
+	start := p.buf.pos
+	hours, err := p.scanDigits(2, "hh")
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(COLON, ":"); err != nil {
+		return nil, err
+	}
+	minutes, err := p.scanDigits(2, "mm")
+	if err != nil {
+		return nil, err
+	}
+	f := field{offset: start, lit: string(p.input[start : p.buf.pos+len(p.buf.lit)])}
+	if sign == "-" {
+		return p.offsetLocation(-1, hours, minutes, 0, f, max)
+	}
+	return p.offsetLocation(1, hours, minutes, 0, f, max)
+}
+
+// expect reads a token that must be tok.
+func (p *parser) expect(tok token, names ...string) error {
+	if got, _ := p.scan(); got != tok {
+		return p.unexpected(names...)
+	}
+	return nil
+}
+
+// RFC3339UTC is like DefaultUTC, but only reads strict RFC 3339 timestamps, which always have an
+// offset.  They're converted to UTC when read, and written in UTC with a Z.
+type RFC3339UTC time.Time
+
+// String returns the RFC3339UTC's RFC3339Nano representation in UTC.
+func (d RFC3339UTC) String() string {
+	return time.Time(d).UTC().Format(time.RFC3339Nano)
+}
+
+// UnmarshalJSON implements the JSON Unmarshaler interface, allowing datetime.RFC3339UTC struct fields
+// to be read from JSON string fields.
+func (d *RFC3339UTC) UnmarshalJSON(data []byte) error {
+	t, err := rfc3339Parser.parseJSON(data)
+	*d = RFC3339UTC(t.UTC())
+	return err
+}
+
+// MarshalJSON implements the JSON Marshaler interface, writing the RFC3339UTC as an RFC3339Nano JSON
+// string in UTC.  The zero value is written as null, to match UnmarshalJSON.
+func (d RFC3339UTC) MarshalJSON() ([]byte, error) {
+	return marshalJSON(time.Time(d).UTC())
+}
+
+// MarshalText implements the encoding TextMarshaler interface.  The zero value is written as an
+// empty string.
+func (d RFC3339UTC) MarshalText() ([]byte, error) {
+	return marshalText(time.Time(d).UTC())
+}
+
+// UnmarshalText implements the encoding TextUnmarshaler interface.  An empty string is read as the
+// zero value.
+func (d *RFC3339UTC) UnmarshalText(data []byte) error {
+	t, err := unmarshalText(data, rfc3339Parser)
+	*d = RFC3339UTC(t.UTC())
+	return err
+}
+
+// Scan implements the sql Scanner interface, allowing datetime.RFC3339UTC fields to be read from
+// database columns.
+func (d *RFC3339UTC) Scan(value interface{}) error {
+	t, err := sqlScan(value, rfc3339Parser)
+	*d = RFC3339UTC(t.UTC())
+	return err
+}
+
+// Value implements the sql Valuer interface, allowing datetime.RFC3339UTC fields to be saved to
+// database columns.
+func (d RFC3339UTC) Value() (driver.Value, error) {
+	return d.String(), nil
+}
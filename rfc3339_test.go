@@ -0,0 +1,187 @@
+package datetime
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRFC3339(t *testing.T) {
+	tt := []struct {
+		input  string
+		output time.Time
+	}{
+		{
+			input:  "2007-11-30T10:10:10Z",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-30t10:10:10z",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-30 10:10:10.5Z",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 500000000, time.UTC),
+		},
+		{
+			input:  "2007-11-30T10:10:10.123456789-05:00",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 123456789, time.FixedZone("-05:00", -5*60*60)),
+		},
+		{
+			input:  "2016-12-31T23:59:60Z",
+			output: time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tt {
+		ts, err := ParseRFC3339(tc.input)
+		assert.Equal(t, tc.output, ts, tc.input)
+		assert.Nil(t, err, tc.input)
+	}
+
+	errs := []struct {
+		input string
+		err   string
+	}{
+		{
+			input: "20071130T101010Z",
+			err:   "found 20071130, expected yyyy",
+		},
+		{
+			input: "2007-11-30",
+			err:   "found , expected T, t, or space",
+		},
+		{
+			input: "2007-11-30T10:10Z",
+			err:   "found Z, expected :",
+		},
+		{
+			input: "2007-11-30T10:10:10",
+			err:   "found , expected Z or timezone offset",
+		},
+		{
+			input: "2007-11-30T10:10:10,5Z",
+			err:   "found ,, expected Z or timezone offset",
+		},
+		{
+			input: "2007-11-30T10:10:10+0500",
+			err:   "found 0500, expected hh",
+		},
+		{
+			input: "2007-11-30T10:10:10+05",
+			err:   "found , expected :",
+		},
+		{
+			input: "2007-334T10:10:10Z",
+			err:   "found 334, expected mm",
+		},
+		{
+			input: "2007-W48-5T10:10:10Z",
+			err:   "found W, expected mm",
+		},
+		{
+			input: "2007-11-30T24:00:00Z",
+			err:   "24 is not a valid hour",
+		},
+		{
+			input: "2007-02-29T10:10:10Z",
+			err:   "29 is not a valid day in February 2007",
+		},
+		{
+			input: "2007-11-30T10:10:10ZZ",
+			err:   "found Z, expected EOF",
+		},
+	}
+
+	for _, tc := range errs {
+		ts, err := ParseRFC3339(tc.input)
+		assert.Equal(t, zeroTime, ts, tc.input)
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+}
+
+func TestRFC3339Option(t *testing.T) {
+	// options that don't change the grammar still apply.
+	p := NewParser(RFC3339(), RejectLeapSeconds())
+	_, err := p.Parse("2016-12-31T23:59:60Z")
+	assert.EqualError(t, err, "60 is not a valid second")
+
+	// a lowercase z is only allowed by RFC 3339.
+	_, err = ParseUTC("2007-11-30T10:10:10z")
+	assert.EqualError(t, err, "expected Z, timezone offset, or EOF. got z")
+}
+
+func TestRFC3339UTCJSON(t *testing.T) {
+	var out struct {
+		T RFC3339UTC `json:"t"`
+	}
+	err := json.Unmarshal([]byte(`{"t":"2007-11-30T10:10:10+01:00"}`), &out)
+	assert.Nil(t, err)
+	assert.Equal(t, RFC3339UTC(time.Date(2007, time.November, 30, 9, 10, 10, 0, time.UTC)), out.T)
+
+	b, err := json.Marshal(out)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"t":"2007-11-30T09:10:10Z"}`, string(b))
+
+	err = json.Unmarshal([]byte(`{"t":"2007-11-30T10:10:10"}`), &out)
+	assert.EqualError(t, err, "found , expected Z or timezone offset")
+	assert.Equal(t, RFC3339UTC{}, out.T)
+
+	err = json.Unmarshal([]byte(`{"t":null}`), &out)
+	assert.Nil(t, err)
+	b, err = json.Marshal(out)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"t":null}`, string(b))
+}
+
+func TestRFC3339UTCText(t *testing.T) {
+	var d RFC3339UTC
+	assert.Nil(t, d.UnmarshalText([]byte("2007-11-30 10:10:10.5-05:00")))
+	assert.Equal(t, "2007-11-30T15:10:10.5Z", d.String())
+
+	b, err := d.MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, "2007-11-30T15:10:10.5Z", string(b))
+
+	assert.Nil(t, d.UnmarshalText([]byte{}))
+	assert.Equal(t, RFC3339UTC{}, d)
+}
+
+func TestRFC3339UTCScanValue(t *testing.T) {
+	tt := []struct {
+		input  interface{}
+		output RFC3339UTC
+		err    string
+	}{
+		{
+			input:  "2007-11-30T10:10:10Z",
+			output: RFC3339UTC(time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC)),
+		},
+		{
+			input:  []byte("2007-11-30T10:10:10-01:00"),
+			output: RFC3339UTC(time.Date(2007, time.November, 30, 11, 10, 10, 0, time.UTC)),
+		},
+		{
+			input: "2007-11-30",
+			err:   "found , expected T, t, or space",
+		},
+		{
+			input: 12,
+			err:   "can only scan string and []byte, not int",
+		},
+	}
+
+	for _, tc := range tt {
+		var d RFC3339UTC
+		err := d.Scan(tc.input)
+		assertErrorMessage(t, tc.err, err)
+		assert.Equal(t, tc.output, d)
+	}
+
+	v, err := RFC3339UTC(time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC)).Value()
+	assert.Nil(t, err)
+	assert.Equal(t, driver.Value("2007-11-30T10:10:10Z"), v)
+}
@@ -18,8 +18,17 @@ const (
 	DASH
 	COLON
 	DOT
+	COMMA
 	PLUS
+	LBRACKET
+	D
+	H
+	M
+	P
+	S
 	T
+	W
+	Y
 	Z
 )
 
@@ -29,27 +38,48 @@ func isNumber(ch rune) bool {
 
 type scanner struct {
 	r *bufio.Reader
+
+	pos   int // byte offset of the next rune
+	size  int // byte size of the last rune read
+	start int // byte offset of the last token scanned
+
+	lit []byte // reused to build number literals
 }
 
 func newScanner(r io.Reader) *scanner {
 	return &scanner{r: bufio.NewReader(r)}
 }
 
+// reset makes the scanner read from r, reusing its buffers.
+func (s *scanner) reset(r io.Reader) {
+	s.r.Reset(r)
+	s.pos, s.size, s.start = 0, 0, 0
+}
+
 // read reads the next rune from the bufferred reader.
 // Returns the rune(0) if an error occurs (or io.EOF is returned).
 func (s *scanner) read() rune {
-	ch, _, err := s.r.ReadRune()
+	ch, size, err := s.r.ReadRune()
 	if err != nil {
+		s.size = 0
 		return eof
 	}
+	s.pos += size
+	s.size = size
 	return ch
 }
 
 // unread places the previously read rune back on the reader.
-func (s *scanner) unread() { _ = s.r.UnreadRune() }
+func (s *scanner) unread() {
+	if s.r.UnreadRune() == nil {
+		s.pos -= s.size
+	}
+}
 
 // scan returns the next token and literal value.
 func (s *scanner) scan() (tok token, lit string) {
+	s.start = s.pos
+
 	// Read the next rune.
 	ch := s.read()
 
@@ -70,21 +100,56 @@ func (s *scanner) scan() (tok token, lit string) {
 		return COLON, string(ch)
 	case '.':
 		return DOT, string(ch)
+	case ',':
+		return COMMA, string(ch)
 	case '+':
 		return PLUS, string(ch)
-	case 'T':
+	case '[':
+		return LBRACKET, string(ch)
+	case 'D':
+		return D, string(ch)
+	case 'H':
+		return H, string(ch)
+	case 'M':
+		return M, string(ch)
+	case 'P':
+		return P, string(ch)
+	case 'S':
+		return S, string(ch)
+	case 'T', 't', ' ':
+		// 't' and ' ' are only allowed as date and time separators, which the parser checks.
 		return T, string(ch)
-	case 'Z':
+	case 'W':
+		return W, string(ch)
+	case 'Y':
+		return Y, string(ch)
+	case 'Z', 'z':
+		// 'z' is only allowed by RFC 3339, which the parser checks.
 		return Z, string(ch)
 	}
 
 	return ILLEGAL, string(ch)
 }
 
-func (s *scanner) scanNumber() (tok token, lit string) {
-	// Create a buffer and read the current character into it.
+// scanBracketed reads everything up to and including the next ']', after a '[' has been scanned, and
+// returns what was between them.  It returns false if there was no ']'.
+func (s *scanner) scanBracketed() (string, bool) {
 	var buf bytes.Buffer
-	buf.WriteRune(s.read())
+	for {
+		switch ch := s.read(); ch {
+		case eof:
+			return buf.String(), false
+		case ']':
+			return buf.String(), true
+		default:
+			buf.WriteRune(ch)
+		}
+	}
+}
+
+func (s *scanner) scanNumber() (tok token, lit string) {
+	// Reset the buffer and read the current character into it.
+	s.lit = append(s.lit[:0], byte(s.read()))
 
 	// Read every subsequent whitespace character into the buffer.
 	// Non-whitespace characters and EOF will cause the loop to exit.
@@ -95,9 +160,9 @@ func (s *scanner) scanNumber() (tok token, lit string) {
 			s.unread()
 			break
 		} else {
-			buf.WriteRune(ch)
+			s.lit = append(s.lit, byte(ch))
 		}
 	}
 
-	return NUMBER, buf.String()
+	return NUMBER, string(s.lit)
 }
@@ -0,0 +1,88 @@
+package datetime
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// locations caches the locations loaded for suffixes, since loading one reads the zoneinfo database.
+var locations sync.Map
+
+// parseSuffix reads the RFC 9557 suffix after the timezone, like [America/New_York][u-ca=gregory],
+// and returns the location named in it, or nil if it didn't name one.  The location must come before
+// any tags.  Unknown tags are ignored unless they're marked critical with a '!'.
+func (p *parser) parseSuffix() (*time.Location, error) {
+	var loc *time.Location
+	for first := true; ; first = false {
+		if tok, _ := p.scan(); tok != LBRACKET {
+			p.unscan()
+			return loc, nil
+		}
+		start := p.buf.pos
+		content, ok := p.s.scanBracketed()
+		f := field{offset: start, lit: string(p.input[start:p.s.pos])}
+		if !ok {
+			return nil, p.errorAt(SyntaxError, f, []string{"]"}, "found %s, expected ]", f.lit)
+		}
+
+		critical := strings.HasPrefix(content, "!")
+		content = strings.TrimPrefix(content, "!")
+		i := strings.IndexByte(content, '=')
+		if i < 0 {
+			if !first {
+				return nil, p.errorAt(SyntaxError, f, []string{"tag"}, "found %s, expected tag", f.lit)
+			}
+			var err error
+			if loc, err = p.suffixLocation(content, f); err != nil {
+				return nil, err
+			}
+			if p.details.Zone == 0 {
+				p.fields.zone = f
+			}
+			continue
+		}
+
+		key, value := content[:i], content[i+1:]
+		if critical && !(key == "u-ca" && (value == "iso8601" || value == "gregory")) {
+			return nil, p.errorAt(UnsupportedError, f, nil, "%s is a critical tag that isn't supported", f.lit)
+		}
+	}
+}
+
+// suffixLocation returns the location for a time zone in a suffix, which may be an IANA name like
+// America/New_York or an offset like +05:00.
+func (p *parser) suffixLocation(name string, f field) (*time.Location, error) {
+	if strings.HasPrefix(name, "+") || strings.HasPrefix(name, "-") {
+		sub := newParser([]byte(name), p.opts)
+		loc, err := sub.parseLocation(nil)
+		if tok, _ := sub.scan(); err != nil || tok != EOF {
+			return nil, p.errorAt(SyntaxError, f, nil, "%s is not a valid timezone offset", name)
+		}
+		return loc, nil
+	}
+
+	if cached, ok := locations.Load(name); ok {
+		return cached.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "" || name == "Local" {
+		return nil, p.errorAt(UnsupportedError, f, nil, "unknown time zone %s", name)
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// inNamedLocation returns t in the location named by the suffix, after checking that any offset it
+// was given with is the one the location has at that time.  A Z means the offset is unknown, so it
+// isn't checked.
+func (p *parser) inNamedLocation(t time.Time, named *time.Location) (time.Time, error) {
+	out := t.In(named)
+	if p.details.Zone == ZoneOffset {
+		_, want := out.Zone()
+		if _, got := t.Zone(); got != want {
+			return zeroTime, p.errorAt(RangeError, p.fields.zone, nil, "%s is not the offset in %s at that time", t.Location(), named)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,122 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSuffix(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+	paris, err := time.LoadLocation("Europe/Paris")
+	assert.Nil(t, err)
+
+	tt := []struct {
+		input  string
+		output time.Time
+		zone   ZoneKind
+	}{
+		{
+			input:  "2007-11-30T10:10:10-05:00[America/New_York]",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, ny),
+			zone:   ZoneOffset,
+		},
+		{
+			input:  "2007-11-30T10:10:10Z[Europe/Paris]",
+			output: time.Date(2007, time.November, 30, 11, 10, 10, 0, paris),
+			zone:   ZoneUTC,
+		},
+		{
+			input:  "2007-11-30T10:10:10[America/New_York]",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, ny),
+			zone:   ZoneNamed,
+		},
+		{
+			input:  "2007-11-30T10[America/New_York]",
+			output: time.Date(2007, time.November, 30, 10, 0, 0, 0, ny),
+			zone:   ZoneNamed,
+		},
+		{
+			input:  "2007-07-30T10:10:10-04[America/New_York][u-ca=gregory]",
+			output: time.Date(2007, time.July, 30, 10, 10, 10, 0, ny),
+			zone:   ZoneOffset,
+		},
+		{
+			input:  "2007-11-30T10:10:10-05:00[America/New_York][!u-ca=iso8601][x-unknown=yes]",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, ny),
+			zone:   ZoneOffset,
+		},
+		{
+			input:  "2007-11-30T10:10:10+05:00[+05:00]",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.FixedZone("+05:00", 5*60*60)),
+			zone:   ZoneOffset,
+		},
+		{
+			input:  "2007-11-30T10:10:10Z[u-ca=japanese]",
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC),
+			zone:   ZoneUTC,
+		},
+	}
+
+	for _, tc := range tt {
+		ts, details, err := ParseDetails(tc.input, time.UTC)
+		assert.Nil(t, err, tc.input)
+		assert.True(t, tc.output.Equal(ts), tc.input)
+		assert.Equal(t, tc.output.Location().String(), ts.Location().String(), tc.input)
+		assert.Equal(t, tc.zone, details.Zone, tc.input)
+	}
+
+	errs := []struct {
+		input string
+		err   string
+	}{
+		{
+			input: "2007-11-30T10:10:10-08:00[America/New_York]",
+			err:   "-08:00 is not the offset in America/New_York at that time",
+		},
+		{
+			input: "2007-11-30T10:10:10Z[Mars/Olympus_Mons]",
+			err:   "unknown time zone Mars/Olympus_Mons",
+		},
+		{
+			input: "2007-11-30T10:10:10Z[!u-ca=japanese]",
+			err:   "[!u-ca=japanese] is a critical tag that isn't supported",
+		},
+		{
+			input: "2007-11-30T10:10:10Z[!x-foo=bar]",
+			err:   "[!x-foo=bar] is a critical tag that isn't supported",
+		},
+		{
+			input: "2007-11-30T10:10:10Z[u-ca=gregory][Europe/Paris]",
+			err:   "found [Europe/Paris], expected tag",
+		},
+		{
+			input: "2007-11-30T10:10:10Z[Europe/Paris",
+			err:   "found [Europe/Paris, expected ]",
+		},
+		{
+			input: "2007-11-30T10:10:10Z[+25:00x]",
+			err:   "+25:00x is not a valid timezone offset",
+		},
+		{
+			input: "2007-11-30T10:10:10Z[]",
+			err:   "unknown time zone ",
+		},
+		{
+			input: "2007-11-30T10:10:10Z[Europe/Paris]Z",
+			err:   "expected EOF. got Z",
+		},
+	}
+
+	for _, tc := range errs {
+		ts, err := ParseUTC(tc.input)
+		assert.Equal(t, zeroTime, ts, tc.input)
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+
+	// a named location isn't a local date and time.
+	_, err = ParseLocalDateTime("2007-11-30T10:10:10[America/New_York]")
+	assert.EqualError(t, err, "found [America/New_York], expected EOF")
+}
@@ -0,0 +1,172 @@
+package datetime
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeOfDay is a time without a date, like an opening hour.  It may have a location, if one was given
+// when it was parsed.  The zero value is midnight without a location.
+type TimeOfDay struct {
+	// Hour is from 0 to 23, or 24 for the end of the day.
+	Hour int
+	// Minute is from 0 to 59.
+	Minute int
+	// Second is from 0 to 59, or 60 for a leap second.
+	Second     int
+	Nanosecond int
+	// Location is the location given with the time, or nil if it didn't have one.
+	Location *time.Location
+}
+
+// ParseTimeOfDay takes a string with an ISO 8601 time in it, like 10:30, T1030, or
+// 10:30:00.5+02:00, and returns a TimeOfDay.  Options that affect times, like RejectLeapSeconds,
+// RequireOffset, and TruncateFractions, are allowed.
+func ParseTimeOfDay(s string, opts ...Option) (TimeOfDay, error) {
+	return parseTimeOfDayBytes([]byte(s), opts)
+}
+
+// TimeOfDayOf returns t's time of day in its location, without the location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Nanosecond: t.Nanosecond()}
+}
+
+// On returns the time.Time at the time of day on the given date.  The TimeOfDay's own location is
+// used if it has one, and otherwise loc is.  24:00 and leap seconds are normalized like time.Date
+// does.
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	if t.Location != nil {
+		loc = t.Location
+	}
+	return time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond, loc)
+}
+
+// sinceMidnight returns how long after midnight the time of day is on the clock.
+func (t TimeOfDay) sinceMidnight() time.Duration {
+	return time.Duration(t.Hour)*time.Hour + time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second + time.Duration(t.Nanosecond)
+}
+
+// Before tells you whether t is earlier on the clock than other.  Their locations are ignored.
+func (t TimeOfDay) Before(other TimeOfDay) bool {
+	return t.sinceMidnight() < other.sinceMidnight()
+}
+
+// After tells you whether t is later on the clock than other.  Their locations are ignored.
+func (t TimeOfDay) After(other TimeOfDay) bool {
+	return other.Before(t)
+}
+
+// String returns the time in the ISO 8601 extended format, like 10:30:00 or 10:30:00.5+02:00.  A
+// time of day has no date to look up a named location's offset on, so the offset it has on
+// 2000-01-01 is written, which may not be what it has in summer.
+func (t TimeOfDay) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond != 0 {
+		b.WriteByte('.')
+		b.WriteString(strings.TrimRight(fmt.Sprintf("%09d", t.Nanosecond), "0"))
+	}
+	if t.Location != nil {
+		// the offset may depend on the date for a named location, so use an arbitrary one.
+		writeOffset(&b, time.Date(2000, time.January, 1, 0, 0, 0, 0, t.Location), OffsetExtended)
+	}
+	return b.String()
+}
+
+// MarshalText implements the encoding TextMarshaler interface.
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements the encoding TextUnmarshaler interface.
+func (t *TimeOfDay) UnmarshalText(data []byte) error {
+	parsed, err := parseTimeOfDayBytes(data, nil)
+	*t = parsed
+	return err
+}
+
+// MarshalJSON implements the JSON Marshaler interface, writing the time as a JSON string.
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + t.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the JSON Unmarshaler interface, allowing datetime.TimeOfDay struct fields
+// to be read from JSON string fields.  null is read as the zero value.
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	// Ignore null, like in the main JSON package.
+	if string(data) == "null" {
+		*t = TimeOfDay{}
+		return nil
+	}
+	trimmed, err := trimQuotes(data)
+	if err != nil {
+		*t = TimeOfDay{}
+		return err
+	}
+	return t.UnmarshalText(trimmed)
+}
+
+// Scan implements the sql Scanner interface, allowing datetime.TimeOfDay fields to be read from TIME
+// database columns, which drivers may return as a time.Time, string, or []byte.  NULL is read as the
+// zero value.
+func (t *TimeOfDay) Scan(value interface{}) error {
+	if value == nil {
+		*t = TimeOfDay{}
+		return nil
+	}
+	if v, ok := value.(time.Time); ok {
+		*t = TimeOfDayOf(v)
+		return nil
+	}
+	b, err := scanBytes(value)
+	if err != nil {
+		*t = TimeOfDay{}
+		return err
+	}
+	return t.UnmarshalText(b)
+}
+
+// Value implements the sql Valuer interface, allowing datetime.TimeOfDay fields to be saved to TIME
+// database columns.
+func (t TimeOfDay) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
+func parseTimeOfDayBytes(b []byte, opts []Option) (TimeOfDay, error) {
+	p := newParser(b, newOptions(opts))
+	parseErr := func(err error) (TimeOfDay, error) {
+		return TimeOfDay{}, err
+	}
+
+	// the T at the start is optional.
+	if tok, lit := p.scan(); tok != T || lit != "T" {
+		p.unscan()
+	}
+	hour, min, sec, frac, err := p.parseTime()
+	if err != nil {
+		return parseErr(err)
+	}
+	loc, err := p.parseLocation(nil)
+	if err != nil {
+		return parseErr(err)
+	}
+	if tok, lit := p.scan(); tok != EOF {
+		return parseErr(p.syntaxError([]string{"EOF"}, "expected EOF. got %s", lit))
+	}
+	if err := p.checkTime(hour, min, sec, frac); err != nil {
+		return parseErr(err)
+	}
+	if err := p.checkPrecision(); err != nil {
+		return parseErr(err)
+	}
+	if frac >= time.Second {
+		// a fraction of an hour or minute, or one that rounded up to a whole second, carries into the
+		// larger fields.  A :60 leap second is kept when it doesn't.
+		d := time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute + time.Duration(sec)*time.Second + frac
+		hour, min, sec, frac = int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second), d%time.Second
+	}
+	return TimeOfDay{Hour: hour, Minute: min, Second: sec, Nanosecond: int(frac), Location: loc}, nil
+}
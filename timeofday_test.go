@@ -0,0 +1,130 @@
+package datetime
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	plus2 := time.FixedZone("+02:00", 2*60*60)
+	tt := []struct {
+		input  string
+		output TimeOfDay
+	}{
+		{input: "10:30", output: TimeOfDay{Hour: 10, Minute: 30}},
+		{input: "T1030", output: TimeOfDay{Hour: 10, Minute: 30}},
+		{input: "103015", output: TimeOfDay{Hour: 10, Minute: 30, Second: 15}},
+		{input: "10", output: TimeOfDay{Hour: 10}},
+		{input: "10.5", output: TimeOfDay{Hour: 10, Minute: 30}},
+		{input: "10:30:00.5+02:00", output: TimeOfDay{Hour: 10, Minute: 30, Nanosecond: 500000000, Location: plus2}},
+		{input: "T10:30Z", output: TimeOfDay{Hour: 10, Minute: 30, Location: time.UTC}},
+		{input: "24:00", output: TimeOfDay{Hour: 24}},
+		{input: "23:59:60", output: TimeOfDay{Hour: 23, Minute: 59, Second: 60}},
+	}
+
+	for _, tc := range tt {
+		tod, err := ParseTimeOfDay(tc.input)
+		assert.Equal(t, tc.output, tod, tc.input)
+		assert.Nil(t, err, tc.input)
+	}
+
+	errs := []struct {
+		input string
+		opts  []Option
+		err   string
+	}{
+		{input: "25:00", err: "25 is not a valid hour"},
+		{input: "10:60", err: "60 is not a valid minute"},
+		{input: "24:01", err: "24 is not a valid hour"},
+		{input: "23:59:60", opts: []Option{RejectLeapSeconds()}, err: "60 is not a valid second"},
+		{input: "10:30", opts: []Option{RequireOffset()}, err: "found , expected Z or timezone offset"},
+		{input: "2007-11-30T10:30", err: "expected colon or EOF. got -"},
+		{input: "t10:30", err: "expected number. got t"},
+		{input: "10:30Q", err: "expected Z, timezone offset, or EOF. got Q"},
+	}
+
+	for _, tc := range errs {
+		tod, err := ParseTimeOfDay(tc.input, tc.opts...)
+		assert.Equal(t, TimeOfDay{}, tod, tc.input)
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+}
+
+func TestTimeOfDay(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	d := Date{2007, time.November, 30}
+
+	tod := TimeOfDay{Hour: 10, Minute: 30}
+	assert.Equal(t, time.Date(2007, time.November, 30, 10, 30, 0, 0, est), tod.On(d, est))
+	assert.Equal(t, time.Date(2007, time.November, 30, 10, 30, 0, 0, time.UTC), TimeOfDay{Hour: 10, Minute: 30, Location: time.UTC}.On(d, est))
+	assert.Equal(t, time.Date(2007, time.December, 1, 0, 0, 0, 0, est), TimeOfDay{Hour: 24}.On(d, est))
+
+	assert.Equal(t, tod, TimeOfDayOf(time.Date(2007, time.November, 30, 10, 30, 0, 0, est)))
+	assert.True(t, tod.Before(TimeOfDay{Hour: 10, Minute: 30, Nanosecond: 1}))
+	assert.True(t, tod.After(TimeOfDay{Hour: 9, Minute: 59}))
+	assert.False(t, tod.After(tod))
+
+	assert.Equal(t, "10:30:00", tod.String())
+	assert.Equal(t, "10:30:00.25Z", TimeOfDay{Hour: 10, Minute: 30, Nanosecond: 250000000, Location: time.UTC}.String())
+	assert.Equal(t, "24:00:00-05:00", TimeOfDay{Hour: 24, Location: est}.String())
+
+	// offsets keep their seconds, so they read back the same.
+	tod, err := ParseTimeOfDay("10:30:00+05:30:15")
+	assert.Nil(t, err)
+	assert.Equal(t, "10:30:00+05:30:15", tod.String())
+	assert.Equal(t, "10:30:00-00:00:30", TimeOfDay{Hour: 10, Minute: 30, Location: time.FixedZone("", -30)}.String())
+
+	// named locations are written with their offset on 2000-01-01, which is winter in New York.
+	ny, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+	assert.Equal(t, "10:30:00-05:00", TimeOfDay{Hour: 10, Minute: 30, Location: ny}.String())
+}
+
+func TestTimeOfDayJSON(t *testing.T) {
+	var out struct {
+		T TimeOfDay `json:"t"`
+	}
+	err := json.Unmarshal([]byte(`{"t":"T1030+0200"}`), &out)
+	assert.Nil(t, err)
+	assert.Equal(t, "10:30:00+02:00", out.T.String())
+
+	b, err := json.Marshal(out)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"t":"10:30:00+02:00"}`, string(b))
+
+	err = json.Unmarshal([]byte(`{"t":null}`), &out)
+	assert.Nil(t, err)
+	assert.Equal(t, TimeOfDay{}, out.T)
+
+	err = json.Unmarshal([]byte(`{"t":"25:00"}`), &out)
+	assert.EqualError(t, err, "25 is not a valid hour")
+}
+
+func TestTimeOfDayScanValue(t *testing.T) {
+	tt := []struct {
+		input  interface{}
+		output TimeOfDay
+		err    string
+	}{
+		{input: "10:30:00", output: TimeOfDay{Hour: 10, Minute: 30}},
+		{input: []byte("10:30:00.123456"), output: TimeOfDay{Hour: 10, Minute: 30, Nanosecond: 123456000}},
+		{input: time.Date(0, time.January, 1, 10, 30, 0, 0, time.UTC), output: TimeOfDay{Hour: 10, Minute: 30}},
+		{input: nil, output: TimeOfDay{}},
+		{input: 12, err: "can only scan string and []byte, not int"},
+	}
+
+	for _, tc := range tt {
+		var tod TimeOfDay
+		err := tod.Scan(tc.input)
+		assertErrorMessage(t, tc.err, err)
+		assert.Equal(t, tc.output, tod)
+	}
+
+	v, err := TimeOfDay{Hour: 10, Minute: 30}.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, driver.Value("10:30:00"), v)
+}
@@ -6,7 +6,6 @@
 package datetime
 
 import (
-	"bytes"
 	"database/sql/driver"
 	"fmt"
 	"reflect"
@@ -20,14 +19,33 @@ type DefaultUTC time.Time
 
 // String returns the DefaultUTC's RFC3339Nano representation.
 func (d DefaultUTC) String() string {
-	t := time.Time(d)
-	return t.Format(time.RFC3339Nano)
+	return formatNano(time.Time(d))
 }
 
 // UnmarshalJSON implements the JSON Unmarshaler interface, allowing datetime.DefaultUTC struct fields
 // to be read from JSON string fields.
 func (d *DefaultUTC) UnmarshalJSON(data []byte) error {
-	t, err := JSONParse(data, time.UTC)
+	t, err := utcParser.parseJSON(data)
+	*d = DefaultUTC(t)
+	return err
+}
+
+// MarshalJSON implements the JSON Marshaler interface, writing the DefaultUTC as an RFC3339Nano JSON
+// string.  The zero value is written as null, to match UnmarshalJSON.
+func (d DefaultUTC) MarshalJSON() ([]byte, error) {
+	return marshalJSON(time.Time(d))
+}
+
+// MarshalText implements the encoding TextMarshaler interface.  The zero value is written as an
+// empty string.
+func (d DefaultUTC) MarshalText() ([]byte, error) {
+	return marshalText(time.Time(d))
+}
+
+// UnmarshalText implements the encoding TextUnmarshaler interface.  An empty string is read as the
+// zero value.
+func (d *DefaultUTC) UnmarshalText(data []byte) error {
+	t, err := unmarshalText(data, utcParser)
 	*d = DefaultUTC(t)
 	return err
 }
@@ -35,7 +53,7 @@ func (d *DefaultUTC) UnmarshalJSON(data []byte) error {
 // Scan implements the sql Scanner interface, allowing datetime.DefaultUTC fields to be read from
 // database columns.
 func (d *DefaultUTC) Scan(value interface{}) error {
-	t, err := sqlScan(value, time.UTC)
+	t, err := sqlScan(value, utcParser)
 	*d = DefaultUTC(t)
 	return err
 }
@@ -53,14 +71,33 @@ type DefaultLocal time.Time
 
 // String returns the DefaultLocal's RFC3339Nano representation.
 func (d DefaultLocal) String() string {
-	t := time.Time(d)
-	return t.Format(time.RFC3339Nano)
+	return formatNano(time.Time(d))
 }
 
 // UnmarshalJSON implements the JSON Unmarshaler interface, allowing datetime.DefaultLocal struct fields
 // to be read from JSON string fields.
 func (d *DefaultLocal) UnmarshalJSON(data []byte) error {
-	t, err := JSONParse(data, time.Local)
+	t, err := localParser.parseJSON(data)
+	*d = DefaultLocal(t)
+	return err
+}
+
+// MarshalJSON implements the JSON Marshaler interface, writing the DefaultLocal as an RFC3339Nano JSON
+// string.  The zero value is written as null, to match UnmarshalJSON.
+func (d DefaultLocal) MarshalJSON() ([]byte, error) {
+	return marshalJSON(time.Time(d))
+}
+
+// MarshalText implements the encoding TextMarshaler interface.  The zero value is written as an
+// empty string.
+func (d DefaultLocal) MarshalText() ([]byte, error) {
+	return marshalText(time.Time(d))
+}
+
+// UnmarshalText implements the encoding TextUnmarshaler interface.  An empty string is read as the
+// zero value.
+func (d *DefaultLocal) UnmarshalText(data []byte) error {
+	t, err := unmarshalText(data, localParser)
 	*d = DefaultLocal(t)
 	return err
 }
@@ -68,7 +105,7 @@ func (d *DefaultLocal) UnmarshalJSON(data []byte) error {
 // Scan implements the sql Scanner interface, allowing datetime.DefaultLocal fields to be read from
 // database columns.
 func (d *DefaultLocal) Scan(value interface{}) error {
-	t, err := sqlScan(value, time.Local)
+	t, err := sqlScan(value, localParser)
 	*d = DefaultLocal(t)
 	return err
 }
@@ -80,42 +117,102 @@ func (d DefaultLocal) Value() (driver.Value, error) {
 }
 
 // Below here are helper funcs used by the DefaultUTC and Local types.
-func parseBytes(b []byte, loc *time.Location) (time.Time, error) {
-	p := newParser(bytes.NewBuffer(b))
-	return p.parse(loc)
+
+// parserFor returns a Parser with the given default location, reusing the preconfigured ones when it
+// can.
+func parserFor(loc *time.Location) *Parser {
+	switch loc {
+	case time.UTC:
+		return utcParser
+	case time.Local:
+		return localParser
+	default:
+		return NewParser(DefaultLocation(loc))
+	}
+}
+
+func sqlScan(value interface{}, p *Parser) (time.Time, error) {
+	b, err := scanBytes(value)
+	if err != nil {
+		return zeroTime, err
+	}
+	return p.ParseBytes(b)
 }
 
-func sqlScan(value interface{}, loc *time.Location) (time.Time, error) {
+// scanBytes returns the bytes of a string or []byte value read from a database column.
+func scanBytes(value interface{}) ([]byte, error) {
 	switch v := value.(type) {
 	case []byte:
-		t, err := parseBytes(v, loc)
-		if err != nil {
-			return zeroTime, err
-		}
-		return t, nil
+		return v, nil
 	case string:
-		t, err := parseBytes([]byte(v), loc)
-		if err != nil {
-			return zeroTime, err
-		}
-		return t, nil
+		return []byte(v), nil
 	default:
-		return zeroTime, fmt.Errorf("can only scan string and []byte, not %v", reflect.TypeOf(value))
+		return nil, &ParseError{
+			Input:    fmt.Sprint(value),
+			Expected: []string{"string", "[]byte"},
+			Category: UnsupportedError,
+			msg:      fmt.Sprintf("can only scan string and []byte, not %v", reflect.TypeOf(value)),
+		}
 	}
 }
 
+func marshalJSON(t time.Time) ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + formatNano(t) + `"`), nil
+}
+
+func marshalText(t time.Time) ([]byte, error) {
+	if t.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(formatNano(t)), nil
+}
+
+func unmarshalText(data []byte, p *Parser) (time.Time, error) {
+	if len(data) == 0 {
+		return zeroTime, nil
+	}
+	return p.ParseBytes(data)
+}
+
 const doubleQuote byte = 34
 
 // JSONParse will take a JSON bytes value with quotes around it, and parse it into a time.Time.
 func JSONParse(data []byte, loc *time.Location) (time.Time, error) {
+	return parserFor(loc).parseJSON(data)
+}
+
+func (p *Parser) parseJSON(data []byte) (time.Time, error) {
 	// Ignore null, like in the main JSON package.
 	if string(data) == "null" {
 		return zeroTime, nil
 	}
-	if data[0] != doubleQuote || data[len(data)-1] != doubleQuote {
-		return zeroTime, fmt.Errorf("%s does not begin and end with double quotes", data)
+	trimmed, err := trimQuotes(data)
+	if err != nil {
+		return zeroTime, err
 	}
-	trimmed := data[1 : len(data)-1]
 
-	return parseBytes(trimmed, loc)
+	t, err := p.ParseBytes(trimmed)
+	if perr, ok := err.(*ParseError); ok {
+		// report the error's position in the quoted JSON, not the string inside it.
+		perr.Input = string(data)
+		perr.Offset++
+	}
+	return t, err
+}
+
+// trimQuotes returns a JSON string value with the double quotes around it removed.
+func trimQuotes(data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != doubleQuote || data[len(data)-1] != doubleQuote {
+		return nil, &ParseError{
+			Input:    string(data),
+			Literal:  string(data),
+			Expected: []string{`"`},
+			Category: SyntaxError,
+			msg:      fmt.Sprintf("%s does not begin and end with double quotes", data),
+		}
+	}
+	return data[1 : len(data)-1], nil
 }
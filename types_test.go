@@ -3,7 +3,6 @@ package datetime
 import (
 	"database/sql/driver"
 	"encoding/json"
-	"errors"
 	"testing"
 	"time"
 
@@ -18,6 +17,15 @@ func newDefaultLocal(year int, month time.Month, day, hour, min, sec, nsec int,
 	return DefaultLocal(time.Date(year, month, day, hour, min, sec, nsec, loc))
 }
 
+// assertErrorMessage checks that err has the expected message, or is nil if that's empty.
+func assertErrorMessage(t *testing.T, expected string, err error) {
+	if expected == "" {
+		assert.Nil(t, err)
+	} else {
+		assert.EqualError(t, err, expected)
+	}
+}
+
 func TestParse(t *testing.T) {
 
 	tt := []struct {
@@ -68,7 +76,7 @@ func TestUnmarshal(t *testing.T) {
 		input []byte
 		dt    DefaultUTC
 		dl    DefaultLocal
-		err   error
+		err   string
 	}{
 		{
 			input: []byte(`"2007-11-11T17:38:12.432Z"`),
@@ -80,13 +88,18 @@ func TestUnmarshal(t *testing.T) {
 			dt:    newDefaultUTC(2007, time.November, 11, 17, 38, 12, 1, time.UTC),
 			dl:    newDefaultLocal(2007, time.November, 11, 17, 38, 12, 1, time.UTC),
 		},
+		{
+			input: []byte(`"2007-11-11T17:38:12,432Z"`),
+			dt:    newDefaultUTC(2007, time.November, 11, 17, 38, 12, 432000000, time.UTC),
+			dl:    newDefaultLocal(2007, time.November, 11, 17, 38, 12, 432000000, time.UTC),
+		},
 		{
 			input: []byte(`2007`),
-			err:   errors.New("2007 does not begin and end with double quotes"),
+			err:   "2007 does not begin and end with double quotes",
 		},
 		{
 			input: []byte(`"A"`),
-			err:   errors.New("found A, expected number"),
+			err:   "found A, expected number",
 		},
 		{
 			input: []byte("null"),
@@ -98,14 +111,115 @@ func TestUnmarshal(t *testing.T) {
 	for _, tc := range tt {
 		var dt DefaultUTC
 		err := json.Unmarshal(tc.input, &dt)
-		assert.Equal(t, tc.err, err)
+		assertErrorMessage(t, tc.err, err)
 		assert.Equal(t, tc.dt, dt)
 
 		var dl DefaultLocal
 		err = json.Unmarshal(tc.input, &dl)
-		assert.Equal(t, tc.err, err)
+		assertErrorMessage(t, tc.err, err)
+		assert.Equal(t, tc.dl, dl)
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	tt := []struct {
+		dt     DefaultUTC
+		dl     DefaultLocal
+		output string
+	}{
+		{
+			dt:     newDefaultUTC(2007, time.November, 11, 17, 38, 12, 432000000, time.UTC),
+			dl:     newDefaultLocal(2007, time.November, 11, 17, 38, 12, 432000000, time.UTC),
+			output: `{"t":"2007-11-11T17:38:12.432Z"}`,
+		},
+		{
+			dt:     newDefaultUTC(2007, time.November, 11, 17, 38, 12, 1, time.FixedZone("+02:00", 2*60*60)),
+			dl:     newDefaultLocal(2007, time.November, 11, 17, 38, 12, 1, time.FixedZone("+02:00", 2*60*60)),
+			output: `{"t":"2007-11-11T17:38:12.000000001+02:00"}`,
+		},
+		{
+			// offset seconds are kept, so the instant reads back the same.
+			dt:     newDefaultUTC(1937, time.June, 30, 10, 0, 0, 0, time.FixedZone("+00:19:32", 19*60+32)),
+			dl:     newDefaultLocal(1937, time.June, 30, 10, 0, 0, 0, time.FixedZone("+00:19:32", 19*60+32)),
+			output: `{"t":"1937-06-30T10:00:00+00:19:32"}`,
+		},
+		{
+			dt:     newDefaultUTC(1937, time.June, 30, 10, 0, 0, 500, time.FixedZone("-00:00:30", -30)),
+			dl:     newDefaultLocal(1937, time.June, 30, 10, 0, 0, 500, time.FixedZone("-00:00:30", -30)),
+			output: `{"t":"1937-06-30T10:00:00.0000005-00:00:30"}`,
+		},
+		{
+			dt:     DefaultUTC(zeroTime),
+			dl:     DefaultLocal(zeroTime),
+			output: `{"t":null}`,
+		},
+	}
+
+	for _, tc := range tt {
+		out, err := json.Marshal(struct {
+			T DefaultUTC `json:"t"`
+		}{tc.dt})
+		assert.Nil(t, err)
+		assert.Equal(t, tc.output, string(out))
+
+		var dt struct {
+			T DefaultUTC `json:"t"`
+		}
+		assert.Nil(t, json.Unmarshal(out, &dt))
+		assert.True(t, time.Time(tc.dt).Equal(time.Time(dt.T)), tc.output)
+
+		out, err = json.Marshal(struct {
+			T DefaultLocal `json:"t"`
+		}{tc.dl})
+		assert.Nil(t, err)
+		assert.Equal(t, tc.output, string(out))
+
+		var dl struct {
+			T DefaultLocal `json:"t"`
+		}
+		assert.Nil(t, json.Unmarshal(out, &dl))
+		assert.True(t, time.Time(tc.dl).Equal(time.Time(dl.T)), tc.output)
+	}
+}
+
+func TestText(t *testing.T) {
+	tt := []struct {
+		dt     DefaultUTC
+		dl     DefaultLocal
+		output string
+	}{
+		{
+			dt:     newDefaultUTC(2007, time.November, 11, 17, 38, 12, 432000000, time.UTC),
+			dl:     newDefaultLocal(2007, time.November, 11, 17, 38, 12, 432000000, time.UTC),
+			output: "2007-11-11T17:38:12.432Z",
+		},
+		{
+			dt:     DefaultUTC(zeroTime),
+			dl:     DefaultLocal(zeroTime),
+			output: "",
+		},
+	}
+
+	for _, tc := range tt {
+		out, err := tc.dt.MarshalText()
+		assert.Nil(t, err)
+		assert.Equal(t, tc.output, string(out))
+
+		var dt DefaultUTC
+		assert.Nil(t, dt.UnmarshalText(out))
+		assert.Equal(t, tc.dt, dt)
+
+		out, err = tc.dl.MarshalText()
+		assert.Nil(t, err)
+		assert.Equal(t, tc.output, string(out))
+
+		var dl DefaultLocal
+		assert.Nil(t, dl.UnmarshalText(out))
 		assert.Equal(t, tc.dl, dl)
 	}
+
+	var dt DefaultUTC
+	assert.EqualError(t, dt.UnmarshalText([]byte("A")), "found A, expected number")
 }
 
 func TestScan(t *testing.T) {
@@ -113,16 +227,21 @@ func TestScan(t *testing.T) {
 		input interface{}
 		dt    DefaultUTC
 		dl    DefaultLocal
-		err   error
+		err   string
 	}{
 		{
 			input: []byte("2007-11-11T17:38:12.432"),
 			dt:    newDefaultUTC(2007, time.November, 11, 17, 38, 12, 432000000, time.UTC),
 			dl:    newDefaultLocal(2007, time.November, 11, 17, 38, 12, 432000000, time.Local),
 		},
+		{
+			input: "2007-11-11T17:38:12,432",
+			dt:    newDefaultUTC(2007, time.November, 11, 17, 38, 12, 432000000, time.UTC),
+			dl:    newDefaultLocal(2007, time.November, 11, 17, 38, 12, 432000000, time.Local),
+		},
 		{
 			input: []byte("invalid"),
-			err:   errors.New("found i, expected number"),
+			err:   "found i, expected number",
 		},
 		{
 			input: "2007-11-11T17:38:12.000000001",
@@ -131,23 +250,23 @@ func TestScan(t *testing.T) {
 		},
 		{
 			input: "invalid",
-			err:   errors.New("found i, expected number"),
+			err:   "found i, expected number",
 		},
 		{
 			input: 2007,
-			err:   errors.New("can only scan string and []byte, not int"),
+			err:   "can only scan string and []byte, not int",
 		},
 	}
 
 	for _, tc := range tt {
 		var dt DefaultUTC
 		err := dt.Scan(tc.input)
-		assert.Equal(t, tc.err, err)
+		assertErrorMessage(t, tc.err, err)
 		assert.Equal(t, tc.dt, dt)
 
 		var dl DefaultLocal
 		err = dl.Scan(tc.input)
-		assert.Equal(t, tc.err, err)
+		assertErrorMessage(t, tc.err, err)
 		assert.Equal(t, tc.dl, dl)
 	}
 }
@@ -0,0 +1,346 @@
+package datetime
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// XSDDateTime makes the Parser accept only XML Schema xs:dateTime values, like
+// 2007-11-30T10:10:10.5-05:00.  Unlike this package's own ISO 8601 subset, years may be negative or
+// have more than four digits, 24:00:00 is the end of the day, and leap seconds aren't allowed.  The
+// timezone is optional, and may be no more than 14 hours from UTC.  Only the extended format is
+// accepted.
+//
+// The DefaultLocation, Lenient, TruncateFractions, and Precisions options still apply, and the other
+// options are ignored.
+func XSDDateTime() Option {
+	return func(o *options) { o.profile = profileXSDDateTime }
+}
+
+// XSDDate makes the Parser accept only XML Schema xs:date values, like 2007-11-30 or
+// 2007-11-30-05:00, which may have a timezone.  The time.Time is midnight at the start of the date.
+// See XSDDateTime for the options that still apply.
+func XSDDate() Option {
+	return func(o *options) { o.profile = profileXSDDate }
+}
+
+// XSDGYearMonth makes the Parser accept only XML Schema xs:gYearMonth values, like 2007-11 or
+// 2007-11Z.  The time.Time is midnight at the start of the month.  See XSDDateTime for the options that
+// still apply.
+func XSDGYearMonth() Option {
+	return func(o *options) { o.profile = profileXSDGYearMonth }
+}
+
+// XMLSchema makes the Parser accept any of the values that XSDDateTime, XSDDate, and XSDGYearMonth
+// do.
+func XMLSchema() Option {
+	return func(o *options) { o.profile = profileXMLSchema }
+}
+
+// W3CDTF makes the Parser accept only the W3C Date and Time Formats profile of ISO 8601 used by Atom
+// and other web formats: YYYY, YYYY-MM, YYYY-MM-DD, or YYYY-MM-DDThh:mm with optional seconds and
+// fraction, where a time must have a Z or ±hh:mm timezone.  See XSDDateTime for the options that
+// still apply, along with RejectLeapSeconds.
+func W3CDTF() Option {
+	return func(o *options) { o.profile = profileW3CDTF }
+}
+
+var (
+	xmlSchemaUTCParser   = NewParser(XMLSchema())
+	xmlSchemaLocalParser = NewParser(XMLSchema(), DefaultLocation(time.Local))
+)
+
+// maxXMLSchemaOffset is the furthest from UTC that an XML Schema timezone can be.
+const maxXMLSchemaOffset = 14 * time.Hour
+
+// parseXMLSchema parses a timestamp following one of the XML Schema or W3C-DTF grammars.
+func (p *parser) parseXMLSchema() (time.Time, error) {
+	kind := p.opts.profile
+	if kind == profileXMLSchema {
+		kind = xmlSchemaKind(p.input)
+	}
+	w3c := kind == profileW3CDTF
+	month, day := 1, 1
+	var hour, min, sec, nsec int
+	hasTime := false
+
+	year, err := p.scanXMLSchemaYear(!w3c)
+	if err != nil {
+		return zeroTime, err
+	}
+	if w3c && p.atEOF() {
+		return p.buildTime(year, time.Month(month), day, hour, min, sec, time.Duration(nsec), p.opts.location)
+	}
+
+	if err := p.expect(DASH, "-"); err != nil {
+		return zeroTime, err
+	}
+	if month, err = p.scanDigits(2, "mm"); err != nil {
+		return zeroTime, err
+	}
+	p.fields.month = p.last(0, -1)
+	p.details.Precision = PrecisionMonth
+
+	if kind != profileXSDGYearMonth && !(w3c && p.atEOF()) {
+		if err := p.expect(DASH, "-"); err != nil {
+			return zeroTime, err
+		}
+		if day, err = p.scanDigits(2, "dd"); err != nil {
+			return zeroTime, err
+		}
+		p.fields.day = p.last(0, -1)
+		p.details.Precision = PrecisionDay
+
+		if kind != profileXSDDate && !(w3c && p.atEOF()) {
+			hasTime = true
+			if hour, min, sec, nsec, err = p.parseXMLSchemaTime(w3c); err != nil {
+				return zeroTime, err
+			}
+		}
+	}
+
+	loc, err := p.parseXMLSchemaZone(w3c && hasTime)
+	if err != nil {
+		return zeroTime, err
+	}
+	if tok, _ := p.scan(); tok != EOF {
+		return zeroTime, p.unexpected("EOF")
+	}
+
+	return p.buildTime(year, time.Month(month), day, hour, min, sec, time.Duration(nsec), loc)
+}
+
+// xmlSchemaKind tells you which of the XML Schema types the input looks like.  An xs:gYearMonth like
+// 2007-11-05:00 can only be told apart from an xs:date by the colon in its timezone.
+func xmlSchemaKind(input []byte) profile {
+	s := strings.TrimPrefix(string(input), "-")
+	if strings.Contains(s, "T") {
+		return profileXSDDateTime
+	}
+	// skip the year and month, which are checked later.
+	i := strings.Index(s, "-")
+	if i < 0 || len(s) < i+3 {
+		return profileXSDDate
+	}
+	rest := s[i+3:]
+	if !strings.HasPrefix(rest, "-") || (len(rest) >= 4 && rest[3] == ':') {
+		return profileXSDGYearMonth
+	}
+	return profileXSDDate
+}
+
+// scanXMLSchemaYear reads a year with at least four digits.  If xsd is true, it may be negative or
+// have more than four digits, as long as it doesn't then start with a zero.
+func (p *parser) scanXMLSchemaYear(xsd bool) (int, error) {
+	tok, lit := p.scan()
+	sign := 1
+	if xsd && tok == DASH {
+		sign = -1
+		tok, lit = p.scan()
+	}
+	if tok != NUMBER || len(lit) < 4 || (len(lit) > 4 && (!xsd || lit[0] == '0')) {
+		return 0, p.unexpected("yyyy")
+	}
+	n, err := strconv.Atoi(lit)
+	if err != nil || !checkYear(sign*n) {
+		return 0, p.errorAt(RangeError, p.last(0, -1), nil, "year %s is outside the range time.Time supports", lit)
+	}
+	if sign < 0 && n == 0 {
+		return 0, p.errorAt(RangeError, p.last(0, -1), nil, "-%s is not a valid year", lit)
+	}
+	p.details.Precision = PrecisionYear
+	return sign * n, nil
+}
+
+// parseXMLSchemaTime reads the time after a date, starting with the T.  In W3C-DTF, the seconds are
+// optional.
+func (p *parser) parseXMLSchemaTime(w3c bool) (int, int, int, int, error) {
+	var hour, min, sec, nsec int
+	var err error
+	parseErr := func(err error) (int, int, int, int, error) {
+		return 0, 0, 0, 0, err
+	}
+
+	if tok, lit := p.scan(); tok != T || lit != "T" {
+		return parseErr(p.unexpected("T"))
+	}
+	if hour, err = p.scanDigits(2, "hh"); err != nil {
+		return parseErr(err)
+	}
+	p.fields.hour = p.last(0, -1)
+	if err := p.expect(COLON, ":"); err != nil {
+		return parseErr(err)
+	}
+	if min, err = p.scanDigits(2, "mm"); err != nil {
+		return parseErr(err)
+	}
+	p.fields.min = p.last(0, -1)
+	p.details.Precision = PrecisionMinute
+
+	if tok, _ := p.scan(); tok != COLON {
+		if !w3c {
+			return parseErr(p.unexpected(":"))
+		}
+		p.unscan()
+		return p.checkXMLSchemaTime(w3c, hour, min, sec, nsec)
+	}
+	if sec, err = p.scanDigits(2, "ss"); err != nil {
+		return parseErr(err)
+	}
+	p.fields.sec = p.last(0, -1)
+	p.details.Precision = PrecisionSecond
+
+	if tok, _ := p.scan(); tok != DOT {
+		p.unscan()
+		return p.checkXMLSchemaTime(w3c, hour, min, sec, nsec)
+	}
+	tok, lit := p.scan()
+	if tok != NUMBER {
+		return parseErr(p.unexpected("fraction of seconds"))
+	}
+	nsec = int(p.fraction(lit, time.Second))
+	p.details.Precision = PrecisionSubsecond
+	p.details.FractionDigits = len(lit)
+	if len(lit) > 9 {
+		p.details.ExcessDigits = lit[9:]
+	}
+	return p.checkXMLSchemaTime(w3c, hour, min, sec, nsec)
+}
+
+// checkXMLSchemaTime rejects the times that are valid in ISO 8601 but not in the profile: XML Schema
+// has no leap seconds, and W3C-DTF has no 24:00.
+func (p *parser) checkXMLSchemaTime(w3c bool, hour, min, sec, nsec int) (int, int, int, int, error) {
+	if w3c && hour == 24 {
+		return 0, 0, 0, 0, p.errorAt(RangeError, p.fields.hour, nil, "%02d is not a valid hour", hour)
+	}
+	if !w3c && sec == 60 {
+		return 0, 0, 0, 0, p.errorAt(RangeError, p.fields.sec, nil, "%02d is not a valid second", sec)
+	}
+	return hour, min, sec, nsec, nil
+}
+
+// parseXMLSchemaZone reads the optional Z or ±hh:mm timezone at the end of the timestamp.
+func (p *parser) parseXMLSchemaZone(required bool) (*time.Location, error) {
+	switch tok, lit := p.scan(); tok {
+	case EOF:
+		if required {
+			return nil, p.unexpected("Z", "timezone offset")
+		}
+		p.unscan()
+		return p.opts.location, nil
+	case Z:
+		if lit != "Z" {
+			return nil, p.unexpected("Z", "timezone offset", "EOF")
+		}
+		p.details.Zone = ZoneUTC
+		p.fields.zone = p.last(0, -1)
+		return time.UTC, nil
+	case PLUS, DASH:
+		return p.scanExtendedOffset(lit, maxXMLSchemaOffset)
+	default:
+		if required {
+			return nil, p.unexpected("Z", "timezone offset")
+		}
+		return nil, p.unexpected("Z", "timezone offset", "EOF")
+	}
+}
+
+// atEOF tells you whether the next token is EOF, and only reads it if it is.
+func (p *parser) atEOF() bool {
+	if tok, _ := p.scan(); tok == EOF {
+		return true
+	}
+	p.unscan()
+	return false
+}
+
+// UnmarshalXML implements the xml Unmarshaler interface, allowing datetime.DefaultUTC struct fields to
+// be read from XML elements holding an xs:dateTime, xs:date, or xs:gYearMonth.  An empty element is
+// read as the zero value.
+func (d *DefaultUTC) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	t, err := unmarshalXML(dec, start, xmlSchemaUTCParser)
+	*d = DefaultUTC(t)
+	return err
+}
+
+// UnmarshalXMLAttr implements the xml UnmarshalerAttr interface, allowing datetime.DefaultUTC struct
+// fields to be read from XML attributes holding an xs:dateTime, xs:date, or xs:gYearMonth.
+func (d *DefaultUTC) UnmarshalXMLAttr(attr xml.Attr) error {
+	t, err := unmarshalText([]byte(strings.TrimSpace(attr.Value)), xmlSchemaUTCParser)
+	*d = DefaultUTC(t)
+	return err
+}
+
+// MarshalXML implements the xml Marshaler interface, writing the DefaultUTC as an RFC3339Nano
+// xs:dateTime.  The zero value is written as an empty element.
+func (d DefaultUTC) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, time.Time(d))
+}
+
+// MarshalXMLAttr implements the xml MarshalerAttr interface, writing the DefaultUTC as an RFC3339Nano
+// xs:dateTime.  The zero value is written as an empty attribute.
+func (d DefaultUTC) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, time.Time(d))
+}
+
+// UnmarshalXML implements the xml Unmarshaler interface, allowing datetime.DefaultLocal struct fields
+// to be read from XML elements holding an xs:dateTime, xs:date, or xs:gYearMonth.  An empty element
+// is read as the zero value.
+func (d *DefaultLocal) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	t, err := unmarshalXML(dec, start, xmlSchemaLocalParser)
+	*d = DefaultLocal(t)
+	return err
+}
+
+// UnmarshalXMLAttr implements the xml UnmarshalerAttr interface, allowing datetime.DefaultLocal
+// struct fields to be read from XML attributes holding an xs:dateTime, xs:date, or xs:gYearMonth.
+func (d *DefaultLocal) UnmarshalXMLAttr(attr xml.Attr) error {
+	t, err := unmarshalText([]byte(strings.TrimSpace(attr.Value)), xmlSchemaLocalParser)
+	*d = DefaultLocal(t)
+	return err
+}
+
+// MarshalXML implements the xml Marshaler interface, writing the DefaultLocal as an RFC3339Nano
+// xs:dateTime.  The zero value is written as an empty element.
+func (d DefaultLocal) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, time.Time(d))
+}
+
+// MarshalXMLAttr implements the xml MarshalerAttr interface, writing the DefaultLocal as an
+// RFC3339Nano xs:dateTime.  The zero value is written as an empty attribute.
+func (d DefaultLocal) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, time.Time(d))
+}
+
+// unmarshalXML reads an element's text and parses it.  XML Schema collapses the whitespace around
+// dates and times, so it's trimmed first.
+func unmarshalXML(dec *xml.Decoder, start xml.StartElement, p *Parser) (time.Time, error) {
+	var s string
+	if err := dec.DecodeElement(&s, &start); err != nil {
+		return zeroTime, err
+	}
+	return unmarshalText([]byte(strings.TrimSpace(s)), p)
+}
+
+func marshalXML(e *xml.Encoder, start xml.StartElement, t time.Time) error {
+	b, err := marshalText(xmlSchemaTime(t))
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(string(b), start)
+}
+
+func marshalXMLAttr(name xml.Name, t time.Time) (xml.Attr, error) {
+	b, err := marshalText(xmlSchemaTime(t))
+	return xml.Attr{Name: name, Value: string(b)}, err
+}
+
+// xmlSchemaTime converts t to UTC if its offset has seconds, which an xs:dateTime can't have.
+func xmlSchemaTime(t time.Time) time.Time {
+	if _, offset := t.Zone(); offset%60 != 0 {
+		return t.UTC()
+	}
+	return t
+}
@@ -0,0 +1,226 @@
+package datetime
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXMLSchemaProfiles(t *testing.T) {
+	est := time.FixedZone("-05:00", -5*60*60)
+	tt := []struct {
+		input  string
+		opt    Option
+		output time.Time
+	}{
+		{
+			input:  "2007-11-30T10:10:10.5-05:00",
+			opt:    XSDDateTime(),
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 500000000, est),
+		},
+		{
+			input:  "2007-11-30T10:10:10",
+			opt:    XSDDateTime(),
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-30T24:00:00Z",
+			opt:    XSDDateTime(),
+			output: time.Date(2007, time.December, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "-0044-03-15T12:00:00Z",
+			opt:    XSDDateTime(),
+			output: time.Date(-44, time.March, 15, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "12007-11-30T10:10:10Z",
+			opt:    XSDDateTime(),
+			output: time.Date(12007, time.November, 30, 10, 10, 10, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-30-05:00",
+			opt:    XSDDate(),
+			output: time.Date(2007, time.November, 30, 0, 0, 0, 0, est),
+		},
+		{
+			input:  "2007-11-30",
+			opt:    XSDDate(),
+			output: time.Date(2007, time.November, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "2007-11Z",
+			opt:    XSDGYearMonth(),
+			output: time.Date(2007, time.November, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-05:00",
+			opt:    XMLSchema(),
+			output: time.Date(2007, time.November, 1, 0, 0, 0, 0, est),
+		},
+		{
+			input:  "2007-11-30-05:00",
+			opt:    XMLSchema(),
+			output: time.Date(2007, time.November, 30, 0, 0, 0, 0, est),
+		},
+		{
+			input:  "2007-11-30T10:10:10Z",
+			opt:    XMLSchema(),
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 0, time.UTC),
+		},
+		{
+			input:  "2007",
+			opt:    W3CDTF(),
+			output: time.Date(2007, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "2007-11",
+			opt:    W3CDTF(),
+			output: time.Date(2007, time.November, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:  "2007-11-30T10:10-05:00",
+			opt:    W3CDTF(),
+			output: time.Date(2007, time.November, 30, 10, 10, 0, 0, est),
+		},
+		{
+			input:  "2007-11-30T10:10:10.25Z",
+			opt:    W3CDTF(),
+			output: time.Date(2007, time.November, 30, 10, 10, 10, 250000000, time.UTC),
+		},
+	}
+
+	for _, tc := range tt {
+		ts, err := NewParser(tc.opt).Parse(tc.input)
+		assert.Equal(t, tc.output, ts, tc.input)
+		assert.Nil(t, err, tc.input)
+	}
+
+	errs := []struct {
+		input string
+		opt   Option
+		err   string
+	}{
+		{
+			input: "20071130T101010Z",
+			opt:   XSDDateTime(),
+			err:   "found T, expected -",
+		},
+		{
+			input: "2007-11-30T10:10Z",
+			opt:   XSDDateTime(),
+			err:   "found Z, expected :",
+		},
+		{
+			input: "2007-11-30T10:10:10,5Z",
+			opt:   XSDDateTime(),
+			err:   "found ,, expected Z, timezone offset, or EOF",
+		},
+		{
+			input: "2016-12-31T23:59:60Z",
+			opt:   XSDDateTime(),
+			err:   "60 is not a valid second",
+		},
+		{
+			input: "2007-11-30T24:00:01Z",
+			opt:   XSDDateTime(),
+			err:   "24 is not a valid hour",
+		},
+		{
+			input: "2007-11-30T10:10:10+14:30",
+			opt:   XSDDateTime(),
+			err:   "+14:30 is more than 14:00 from UTC",
+		},
+		{
+			input: "2007-11-30T10:10:10+05",
+			opt:   XSDDateTime(),
+			err:   "found , expected :",
+		},
+		{
+			input: "02007-11-30T10:10:10Z",
+			opt:   XSDDateTime(),
+			err:   "found 02007, expected yyyy",
+		},
+		{
+			input: "-0000-11-30T10:10:10Z",
+			opt:   XSDDateTime(),
+			err:   "-0000 is not a valid year",
+		},
+		{
+			input: "2007-11-30T10:10:10Z",
+			opt:   XSDDate(),
+			err:   "found T, expected Z, timezone offset, or EOF",
+		},
+		{
+			input: "2007-11-30",
+			opt:   XSDGYearMonth(),
+			err:   "found , expected :",
+		},
+		{
+			input: "2007-11-30T10:10",
+			opt:   W3CDTF(),
+			err:   "found , expected Z or timezone offset",
+		},
+		{
+			input: "2007-11-30Z",
+			opt:   W3CDTF(),
+			err:   "found Z, expected T",
+		},
+		{
+			input: "-0044",
+			opt:   W3CDTF(),
+			err:   "found -, expected yyyy",
+		},
+	}
+
+	for _, tc := range errs {
+		ts, err := NewParser(tc.opt).Parse(tc.input)
+		assert.Equal(t, zeroTime, ts, tc.input)
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+}
+
+func TestXML(t *testing.T) {
+	type feed struct {
+		XMLName xml.Name     `xml:"feed"`
+		Updated DefaultUTC   `xml:"updated,attr"`
+		Date    DefaultUTC   `xml:"date"`
+		Local   DefaultLocal `xml:"local"`
+	}
+
+	var out feed
+	err := xml.Unmarshal([]byte(`<feed updated="2007-11-30T10:10:10Z"><date> 2007-11-30 </date><local>2007-11-30T10:10:10-05:00</local></feed>`), &out)
+	assert.Nil(t, err)
+	assert.Equal(t, newDefaultUTC(2007, time.November, 30, 10, 10, 10, 0, time.UTC), out.Updated)
+	assert.Equal(t, newDefaultUTC(2007, time.November, 30, 0, 0, 0, 0, time.UTC), out.Date)
+	assert.Equal(t, "2007-11-30T10:10:10-05:00", out.Local.String())
+
+	b, err := xml.Marshal(out)
+	assert.Nil(t, err)
+	assert.Equal(t, `<feed updated="2007-11-30T10:10:10Z"><date>2007-11-30T00:00:00Z</date><local>2007-11-30T10:10:10-05:00</local></feed>`, string(b))
+
+	// xs:dateTime offsets can't have seconds, so those times are written in UTC.
+	amsterdam := time.FixedZone("+00:19:32", 19*60+32)
+	b, err = xml.Marshal(feed{
+		Updated: newDefaultUTC(1937, time.June, 30, 10, 0, 0, 0, amsterdam),
+		Local:   newDefaultLocal(1937, time.June, 30, 10, 0, 0, 0, amsterdam),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, `<feed updated="1937-06-30T09:40:28Z"><date></date><local>1937-06-30T09:40:28Z</local></feed>`, string(b))
+
+	out = feed{}
+	b, err = xml.Marshal(out)
+	assert.Nil(t, err)
+	assert.Equal(t, `<feed updated=""><date></date><local></local></feed>`, string(b))
+
+	err = xml.Unmarshal(b, &out)
+	assert.Nil(t, err)
+	assert.Equal(t, feed{XMLName: xml.Name{Local: "feed"}}, out)
+
+	err = xml.Unmarshal([]byte(`<feed updated="20071130"></feed>`), &out)
+	assert.EqualError(t, err, "found , expected -")
+	err = xml.Unmarshal([]byte(`<feed><date>2007-11-30T10:10:10+15:00</date></feed>`), &out)
+	assert.EqualError(t, err, "+15:00 is more than 14:00 from UTC")
+}